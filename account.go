@@ -0,0 +1,49 @@
+package gocollect
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AccountService handles communication with the account related endpoints
+type AccountService struct {
+	client *Client
+}
+
+// Usage represents the account's API quota for the current window
+type Usage struct {
+	Used     int       `json:"used"`
+	Limit    int       `json:"limit"`
+	ResetsAt time.Time `json:"resets_at"`
+
+	// Raw is the JSON the record was decoded from, set when WithCaptureRaw
+	// is enabled
+	Raw json.RawMessage `json:"-"`
+}
+
+// Remaining returns the number of calls left before the quota resets
+func (u *Usage) Remaining() int {
+	if u.Used >= u.Limit {
+		return 0
+	}
+	return u.Limit - u.Used
+}
+
+// GetUsage retrieves the authenticated account's API usage. An invalid token
+// yields an error matching ErrUnauthorized.
+func (s *AccountService) GetUsage(ctx context.Context) (*Usage, error) {
+	req, err := s.client.newRequest(ctx, "GET", s.client.apiBase(APIAccount)+"/usage", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data Usage `json:"data"`
+	}
+	_, err = s.client.do(req, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
@@ -0,0 +1,164 @@
+package gocollect
+
+import (
+	"context"
+	"time"
+)
+
+// ActivityType identifies the kind of record behind an ActivityEvent
+type ActivityType string
+
+const (
+	ActivityTypeSoldExample ActivityType = "sold_example"
+	ActivityTypeStagedSale  ActivityType = "staged_sale"
+)
+
+// ActivityEvent is one entry of an activity feed
+type ActivityEvent struct {
+	Type ActivityType
+	// Time is the sale time of a sold example and the last update of a
+	// staged sale, zero if the API did not report it
+	Time time.Time
+	// Ref is a *SoldExample or a *StagedSale, according to Type
+	Ref interface{}
+}
+
+// ActivityFeedOptions represents the filters applied to each source of an
+// activity feed. Their Sort fields are ignored.
+type ActivityFeedOptions struct {
+	SoldExamples ListSoldExamplesOptions
+	StagedSales  ListStagedSalesOptions
+}
+
+// ActivityIterator merges sold examples and staged sales into a single
+// stream ordered by event time, newest first: sold examples by sale time and
+// staged sales, which are mostly still listed, by their last update
+//
+//	it := client.ActivityFeed(ctx, opts)
+//	for it.Next() {
+//		event := it.Event()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ActivityIterator struct {
+	ctx    context.Context
+	client *Client
+	opts   ActivityFeedOptions
+
+	sold   activitySource
+	staged activitySource
+	event  ActivityEvent
+	err    error
+}
+
+// activitySource buffers one page of events from a list endpoint
+type activitySource struct {
+	buf  []ActivityEvent
+	meta *ListMeta
+	done bool
+}
+
+// ActivityFeed returns an iterator over sold examples and staged sales
+// matching opts. Both sources are paged lazily as the iterator advances.
+func (c *Client) ActivityFeed(ctx context.Context, opts ActivityFeedOptions) *ActivityIterator {
+	opts.SoldExamples.Sort = "-sold_at"
+	opts.StagedSales.Sort = "-updated_at"
+	return &ActivityIterator{ctx: ctx, client: c, opts: opts}
+}
+
+// Next advances to the next event, returning false when the feed is
+// exhausted or an error occurred
+func (it *ActivityIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.fill(&it.sold, it.fetchSold); err != nil {
+		it.err = err
+		return false
+	}
+	if err := it.fill(&it.staged, it.fetchStaged); err != nil {
+		it.err = err
+		return false
+	}
+
+	var src *activitySource
+	switch {
+	case len(it.sold.buf) == 0 && len(it.staged.buf) == 0:
+		return false
+	case len(it.staged.buf) == 0:
+		src = &it.sold
+	case len(it.sold.buf) == 0:
+		src = &it.staged
+	case it.staged.buf[0].Time.After(it.sold.buf[0].Time):
+		src = &it.staged
+	default:
+		src = &it.sold
+	}
+
+	it.event = src.buf[0]
+	src.buf = src.buf[1:]
+	return true
+}
+
+// Event returns the current event
+func (it *ActivityIterator) Event() ActivityEvent {
+	return it.event
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ActivityIterator) Err() error {
+	return it.err
+}
+
+// fill fetches the next page of a source once its buffer is empty
+func (it *ActivityIterator) fill(src *activitySource, fetch func(prev *ListMeta) ([]ActivityEvent, *ListMeta, error)) error {
+	for len(src.buf) == 0 && !src.done {
+		events, meta, err := fetch(src.meta)
+		if err != nil {
+			return err
+		}
+		src.buf = events
+		src.meta = meta
+		src.done = !meta.HasNextPage()
+	}
+	return nil
+}
+
+func (it *ActivityIterator) fetchSold(prev *ListMeta) ([]ActivityEvent, *ListMeta, error) {
+	opts := it.opts.SoldExamples
+	opts.Page = 1
+	if prev != nil {
+		opts.nextPage(prev)
+	}
+	examples, meta, err := it.client.SoldExamples.ListSoldExamples(it.ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	events := make([]ActivityEvent, len(examples))
+	for i := range examples {
+		events[i] = ActivityEvent{Type: ActivityTypeSoldExample, Time: examples[i].SoldAt, Ref: &examples[i]}
+	}
+	return events, meta, nil
+}
+
+func (it *ActivityIterator) fetchStaged(prev *ListMeta) ([]ActivityEvent, *ListMeta, error) {
+	opts := it.opts.StagedSales
+	opts.Page = 1
+	if prev != nil {
+		opts.nextPage(prev)
+	}
+	sales, meta, err := it.client.StagedSales.ListStagedSales(it.ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	events := make([]ActivityEvent, len(sales))
+	for i := range sales {
+		events[i] = ActivityEvent{Type: ActivityTypeStagedSale, Ref: &sales[i]}
+		if sales[i].UpdatedAt != nil {
+			events[i].Time = *sales[i].UpdatedAt
+		}
+	}
+	return events, meta, nil
+}
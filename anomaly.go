@@ -0,0 +1,47 @@
+package gocollect
+
+// DefaultAnomalyThreshold is the ratio to FMV beyond which Anomaly flags a
+// sale: at least twice or at most half the FMV
+const DefaultAnomalyThreshold = 2.0
+
+// AnomalyInfo describes how a sale's price compares with the item's FMV
+type AnomalyInfo struct {
+	// Known is false when no FMV was available to compare with; the other
+	// fields are then zero
+	Known bool
+	// RatioToFMV is the sold price divided by the FMV
+	RatioToFMV float64
+	// IsOutlier reports whether the ratio is beyond the threshold in either
+	// direction
+	IsOutlier bool
+	// IsHigh reports whether the sale is above the FMV
+	IsHigh bool
+}
+
+// Anomaly compares the sale's price with fmv using DefaultAnomalyThreshold
+func (e SoldExample) Anomaly(fmv *float64) AnomalyInfo {
+	return e.AnomalyWithThreshold(fmv, DefaultAnomalyThreshold)
+}
+
+// AnomalyWithThreshold compares the sale's price with fmv, flagging it as
+// an outlier when it is at least threshold times the FMV or at most the FMV
+// divided by threshold; a threshold below 1 is treated as its inverse. A nil
+// or non-positive fmv gives an unknown result.
+func (e SoldExample) AnomalyWithThreshold(fmv *float64, threshold float64) AnomalyInfo {
+	if fmv == nil || *fmv <= 0 {
+		return AnomalyInfo{}
+	}
+	if threshold <= 0 {
+		threshold = DefaultAnomalyThreshold
+	} else if threshold < 1 {
+		threshold = 1 / threshold
+	}
+
+	ratio := e.SoldPrice / *fmv
+	return AnomalyInfo{
+		Known:      true,
+		RatioToFMV: ratio,
+		IsOutlier:  ratio >= threshold || ratio <= 1/threshold,
+		IsHigh:     ratio > 1,
+	}
+}
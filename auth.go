@@ -0,0 +1,62 @@
+package gocollect
+
+import (
+	"context"
+	"net/http"
+)
+
+// TokenSource supplies the API token for each request, for tokens that are
+// rotated or fetched from a secret store
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc adapts a function to a TokenSource
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx)
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// WithTokenSource takes the API token of each request from ts instead of the
+// token passed to NewClient, which may then be empty
+func WithTokenSource(ts TokenSource) ClientOption {
+	return func(c *Client) error {
+		c.tokenSource = ts
+		return nil
+	}
+}
+
+// WithoutAuth sends requests without an Authorization header, e.g. to a
+// proxy that adds its own credentials. NewClient then accepts an empty token.
+func WithoutAuth() ClientOption {
+	return func(c *Client) error {
+		c.withoutAuth = true
+		return nil
+	}
+}
+
+// validateAuth checks that the client has a way to authenticate requests
+func (c *Client) validateAuth() error {
+	if c.token == "" && c.tokenSource == nil && !c.withoutAuth {
+		return ErrEmptyToken
+	}
+	return nil
+}
+
+// authorize sets the Authorization header of req
+func (c *Client) authorize(req *http.Request) error {
+	if c.withoutAuth {
+		return nil
+	}
+	token := c.token
+	if c.tokenSource != nil {
+		var err error
+		if token, err = c.tokenSource.Token(req.Context()); err != nil {
+			return err
+		}
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
@@ -0,0 +1,69 @@
+package gocollect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestNewClientEmptyToken(t *testing.T) {
+	tokenSource := TokenSourceFunc(func(ctx context.Context) (string, error) {
+		return "from-source", nil
+	})
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		wantErr error
+	}{
+		{name: "no escape hatch", wantErr: ErrEmptyToken},
+		{name: "without auth", opts: []ClientOption{WithoutAuth()}},
+		{name: "token source", opts: []ClientOption{WithTokenSource(tokenSource)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient("", tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewClient error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && c != nil {
+				t.Error("NewClient returned a client along with the error")
+			}
+		})
+	}
+}
+
+func TestAuthorizationHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ClientOption
+		want string
+	}{
+		{name: "static token", want: "Bearer test-token"},
+		{name: "without auth", opts: []ClientOption{WithoutAuth()}, want: ""},
+		{
+			name: "token source",
+			opts: []ClientOption{WithTokenSource(TokenSourceFunc(func(ctx context.Context) (string, error) {
+				return "rotated", nil
+			}))},
+			want: "Bearer rotated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Authorization")
+				w.Write([]byte(`{"data":{}}`))
+			}, tt.opts...)
+			if _, err := c.Account.GetUsage(context.Background()); err != nil {
+				t.Fatalf("GetUsage: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Authorization = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package gocollect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// batchConcurrency bounds the number of concurrent requests made by batch helpers
+const batchConcurrency = 4
+
+// forEachConcurrently calls fn for each index in [0, n) from at most
+// batchConcurrency workers. Indexes not started before ctx is done are
+// passed to canceled instead.
+func forEachConcurrently(ctx context.Context, n int, fn func(i int), canceled func(i int, err error)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < batchConcurrency && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	next := 0
+dispatch:
+	for ; next < n && ctx.Err() == nil; next++ {
+		select {
+		case indexes <- next:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i := next; i < n; i++ {
+		canceled(i, ctx.Err())
+	}
+}
+
+// uniqueStrings returns values without duplicates, in first-seen order
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// BatchStatus is the outcome of one operation in a batch
+type BatchStatus string
+
+const (
+	BatchStatusDeleted  BatchStatus = "deleted"
+	BatchStatusNotFound BatchStatus = "not_found"
+	BatchStatusFailed   BatchStatus = "failed"
+)
+
+// BatchItemResult is the outcome of the operation on one id of a batch
+type BatchItemResult struct {
+	ID     string
+	Status BatchStatus
+	// Err is set when Status is BatchStatusFailed
+	Err error
+}
+
+// BatchResult reports the per-id outcomes of a batch operation, in the
+// order the ids were given
+type BatchResult struct {
+	Results []BatchItemResult
+}
+
+// IDs returns the ids that ended with the given status
+func (r *BatchResult) IDs(status BatchStatus) []string {
+	var ids []string
+	for _, item := range r.Results {
+		if item.Status == status {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+// Err summarizes the failed operations, or returns nil if none failed
+func (r *BatchResult) Err() error {
+	var failed []string
+	for _, item := range r.Results {
+		if item.Status == BatchStatusFailed {
+			failed = append(failed, fmt.Sprintf("%s: %v", item.ID, item.Err))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("gocollect: %d of %d batch operations failed: %s", len(failed), len(r.Results), strings.Join(failed, "; "))
+}
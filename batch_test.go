@@ -0,0 +1,65 @@
+package gocollect
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestForEachConcurrently(t *testing.T) {
+	const n = 1000
+	var running, maxRunning, maxGoroutines int32
+	var calls [n]int32
+	base := runtime.NumGoroutine()
+	forEachConcurrently(context.Background(), n, func(i int) {
+		r := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if r <= m || atomic.CompareAndSwapInt32(&maxRunning, m, r) {
+				break
+			}
+		}
+		if g := int32(runtime.NumGoroutine() - base); g > atomic.LoadInt32(&maxGoroutines) {
+			atomic.StoreInt32(&maxGoroutines, g)
+		}
+		atomic.AddInt32(&calls[i], 1)
+	}, func(i int, err error) {
+		t.Errorf("index %d canceled: %v", i, err)
+	})
+
+	for i, c := range calls {
+		if c != 1 {
+			t.Fatalf("index %d called %d times, want once", i, c)
+		}
+	}
+	if maxRunning > batchConcurrency {
+		t.Errorf("%d calls ran at once, want at most %d", maxRunning, batchConcurrency)
+	}
+	if maxGoroutines > batchConcurrency {
+		t.Errorf("%d goroutines started, want at most %d", maxGoroutines, batchConcurrency)
+	}
+}
+
+func TestForEachConcurrentlyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var mu sync.Mutex
+	var canceled []int
+	forEachConcurrently(ctx, 10, func(i int) {
+		t.Errorf("index %d called after cancellation", i)
+	}, func(i int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != context.Canceled {
+			t.Errorf("index %d canceled with %v, want context.Canceled", i, err)
+		}
+		canceled = append(canceled, i)
+	})
+	if len(canceled) != 10 {
+		t.Errorf("canceled %v, want all 10 indexes", canceled)
+	}
+}
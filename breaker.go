@@ -0,0 +1,158 @@
+package gocollect
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BreakerState is the state of the client's circuit breaker
+type BreakerState int
+
+const (
+	// BreakerClosed lets requests through while counting failures
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects requests with ErrCircuitOpen until the cooldown ends
+	BreakerOpen
+	// BreakerHalfOpen lets a single trial request through to probe the API
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// WithCircuitBreaker stops sending requests for cooldown after threshold
+// consecutive attempts fail with a network error, a 429 or a 5xx response.
+// Calls made while the breaker is open fail immediately with ErrCircuitOpen.
+// After the cooldown a single trial request is let through: the breaker
+// closes if it succeeds and opens again if it fails.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) ClientOption {
+	return func(c *Client) error {
+		if threshold <= 0 {
+			return fmt.Errorf("gocollect: circuit breaker threshold must be positive, got %d", threshold)
+		}
+		if cooldown <= 0 {
+			return fmt.Errorf("gocollect: circuit breaker cooldown must be positive, got %s", cooldown)
+		}
+		if c.breaker == nil {
+			c.breaker = &circuitBreaker{}
+		}
+		c.breaker.threshold = threshold
+		c.breaker.cooldown = cooldown
+		return nil
+	}
+}
+
+// WithBreakerStateChange registers a callback invoked whenever the circuit
+// breaker changes state, e.g. to alert when it opens. The callback runs
+// synchronously on the goroutine of the request that caused the transition,
+// outside of the breaker's lock. It has no effect without WithCircuitBreaker.
+func WithBreakerStateChange(fn func(from, to BreakerState)) ClientOption {
+	return func(c *Client) error {
+		if c.breaker == nil {
+			c.breaker = &circuitBreaker{}
+		}
+		c.breaker.onStateChange = fn
+		return nil
+	}
+}
+
+// circuitBreaker implements a consecutive-failure circuit breaker. A nil
+// breaker, or one without a threshold, allows every request.
+type circuitBreaker struct {
+	threshold     int
+	cooldown      time.Duration
+	onStateChange func(from, to BreakerState)
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// allow reports whether a request may be sent, returning ErrCircuitOpen if not
+func (b *circuitBreaker) allow() error {
+	if b == nil || b.threshold == 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	from := b.state
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.trial = true
+	case BreakerHalfOpen:
+		if b.trial {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+	return nil
+}
+
+// record updates the breaker with the outcome of an allowed request
+func (b *circuitBreaker) record(failed bool) {
+	if b == nil || b.threshold == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	from := b.state
+	switch {
+	case !failed:
+		b.failures = 0
+		b.state = BreakerClosed
+	case b.state == BreakerHalfOpen:
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	default:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.state = BreakerOpen
+			b.openedAt = time.Now()
+		}
+	}
+	b.trial = false
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+// release ends an allowed request whose outcome says nothing about the API,
+// such as one canceled by its context
+func (b *circuitBreaker) release() {
+	if b == nil || b.threshold == 0 {
+		return
+	}
+	b.mu.Lock()
+	b.trial = false
+	b.mu.Unlock()
+}
+
+// notify invokes the state change callback if the state changed
+func (b *circuitBreaker) notify(from, to BreakerState) {
+	if from != to && b.onStateChange != nil {
+		b.onStateChange(from, to)
+	}
+}
@@ -0,0 +1,79 @@
+package gocollect
+
+import (
+	"context"
+	"strings"
+)
+
+// Catalog holds the enumerations the API currently accepts, as published by
+// its catalog endpoint. Use it to validate input against the server's
+// vocabulary rather than the constants compiled into this package.
+type Catalog struct {
+	CAMs                   []string                          `json:"cams"`
+	CertificationCompanies []CertificationCompany            `json:"certification_companies"`
+	Grades                 map[CertificationCompany][]string `json:"grades"`
+	Labels                 map[CertificationCompany][]string `json:"labels"`
+}
+
+// LoadCatalog retrieves the current catalog of CAMs, certification
+// companies, grades and labels
+func (c *Client) LoadCatalog(ctx context.Context) (*Catalog, error) {
+	req, err := c.newRequest(ctx, "GET", c.apiBase(APICollectibles)+"/catalog", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data Catalog `json:"data"`
+	}
+	_, err = c.do(req, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// HasCAM reports whether cam is in the catalog, ignoring case
+func (cat *Catalog) HasCAM(cam string) bool {
+	return containsFold(cat.CAMs, cam)
+}
+
+// HasCertificationCompany reports whether company is in the catalog, ignoring case
+func (cat *Catalog) HasCertificationCompany(company CertificationCompany) bool {
+	for _, c := range cat.CertificationCompanies {
+		if strings.EqualFold(string(c), string(company)) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasGrade reports whether grade is in the company's grade vocabulary
+func (cat *Catalog) HasGrade(company CertificationCompany, grade string) bool {
+	return containsFold(cat.Grades[company], grade)
+}
+
+// HasLabel reports whether label is valid for the company
+func (cat *Catalog) HasLabel(company CertificationCompany, label string) bool {
+	return containsFold(cat.Labels[company], label)
+}
+
+// CompanyLabelOptions returns client options allowing every company and
+// label combination in the catalog when validating insights requests
+func (cat *Catalog) CompanyLabelOptions() []ClientOption {
+	opts := make([]ClientOption, 0, len(cat.Labels))
+	for company, labels := range cat.Labels {
+		opts = append(opts, WithCompanyLabels(string(company), labels...))
+	}
+	return opts
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package gocollect
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CertificationCompany identifies a grading company
+type CertificationCompany string
+
+const (
+	CertificationCompanyCGC  CertificationCompany = "CGC"
+	CertificationCompanyCBCS CertificationCompany = "CBCS"
+	CertificationCompanyPGX  CertificationCompany = "PGX"
+)
+
+// cbcsKeyPattern matches a CBCS certification number with separators removed
+var cbcsKeyPattern = regexp.MustCompile(`^([0-9]{2})([0-9A-Z]{5,8})([0-9]{3})$`)
+
+// NormalizeCertificationKey returns the canonical form of a certification
+// number for the given company, so the same certificate is always submitted
+// the same way:
+//
+//   - CGC: 10 digits, left-padded with zeros (e.g. "3812345001")
+//   - CBCS: upper case, dash-separated (e.g. "19-1A2B3C4-001")
+//   - PGX: digits without leading zeros
+//
+// Spaces and dashes in key are ignored. An error is returned for an unknown
+// company or a key that cannot be in that company's format.
+func NormalizeCertificationKey(company CertificationCompany, key string) (string, error) {
+	compact := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(key)))
+	if compact == "" {
+		return "", fmt.Errorf("gocollect: empty %s certification key", company)
+	}
+
+	switch CertificationCompany(strings.ToUpper(string(company))) {
+	case CertificationCompanyCGC:
+		if !isDigits(compact) || len(compact) > 10 {
+			return "", fmt.Errorf("gocollect: invalid CGC certification key %q", key)
+		}
+		return strings.Repeat("0", 10-len(compact)) + compact, nil
+	case CertificationCompanyCBCS:
+		m := cbcsKeyPattern.FindStringSubmatch(compact)
+		if m == nil {
+			return "", fmt.Errorf("gocollect: invalid CBCS certification key %q", key)
+		}
+		return m[1] + "-" + m[2] + "-" + m[3], nil
+	case CertificationCompanyPGX:
+		if !isDigits(compact) {
+			return "", fmt.Errorf("gocollect: invalid PGX certification key %q", key)
+		}
+		trimmed := strings.TrimLeft(compact, "0")
+		if trimmed == "" {
+			return "", fmt.Errorf("gocollect: invalid PGX certification key %q", key)
+		}
+		return trimmed, nil
+	default:
+		return "", fmt.Errorf("gocollect: unknown certification company %q", company)
+	}
+}
+
+// isDigits reports whether s consists only of ASCII digits
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isKnownCertificationCompany reports whether keys of company can be normalized
+func isKnownCertificationCompany(company CertificationCompany) bool {
+	switch CertificationCompany(strings.ToUpper(string(company))) {
+	case CertificationCompanyCGC, CertificationCompanyCBCS, CertificationCompanyPGX:
+		return true
+	}
+	return false
+}
+
+// normalizedCertificationKey returns key normalized for company. Keys of
+// companies without a known format are returned unchanged.
+func normalizedCertificationKey(company CertificationCompany, key *string) (*string, error) {
+	if key == nil || !isKnownCertificationCompany(company) {
+		return key, nil
+	}
+	normalized, err := NormalizeCertificationKey(company, *key)
+	if err != nil {
+		return nil, err
+	}
+	return &normalized, nil
+}
@@ -0,0 +1,140 @@
+package gocollect
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// coalescedWriteTimeout bounds a coalesced write, which no single caller's
+// context can cancel
+const coalescedWriteTimeout = time.Minute
+
+// WithWriteCoalescing collapses overlapping UpdateStagedSale calls for the
+// same partner sale id. Updates for a sale are sent one at a time; an update
+// identical to the one in flight shares its result, and of the updates
+// queued behind it only the latest with the same expected version is sent,
+// its result returned to every caller it superseded. A coalesced update is
+// not canceled with the context of any one caller, so that canceling one
+// call does not fail the others, and is bounded to a minute unless
+// WithRequestTimeout sets a timeout; each caller stops waiting when its own
+// context is done. Updates of sales without a partner sale id are never
+// coalesced.
+func WithWriteCoalescing(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.coalescer = nil
+		if enabled {
+			c.coalescer = &writeCoalescer{keys: make(map[string]*coalescedKey)}
+		}
+		return nil
+	}
+}
+
+// writeCoalescer serializes and merges staged sale writes per key
+type writeCoalescer struct {
+	mu   sync.Mutex
+	keys map[string]*coalescedKey
+}
+
+// coalescedKey is the write in flight for a key and the one queued behind it
+type coalescedKey struct {
+	inflight *coalescedWrite
+	pending  *coalescedWrite
+}
+
+// coalescedWrite is one API call whose result is shared by its callers. ctx
+// supplies the request options of the call, not its cancellation.
+type coalescedWrite struct {
+	sig     string
+	version string
+	ctx     context.Context
+	fn      func(ctx context.Context) (*StagedSale, error)
+	done    chan struct{}
+	sale    *StagedSale
+	err     error
+}
+
+// do runs fn for key, coalescing it with the writes in flight or queued for
+// the same key. sig identifies the payload and version its If-Match.
+func (w *writeCoalescer) do(ctx context.Context, key, sig, version string, fn func(ctx context.Context) (*StagedSale, error)) (*StagedSale, error) {
+	for {
+		w.mu.Lock()
+		k := w.keys[key]
+		if k == nil {
+			k = new(coalescedKey)
+			w.keys[key] = k
+		}
+
+		var call *coalescedWrite
+		switch {
+		case k.inflight == nil:
+			call = &coalescedWrite{sig: sig, version: version, ctx: ctx, fn: fn, done: make(chan struct{})}
+			k.inflight = call
+			w.mu.Unlock()
+			go w.run(key, call)
+		case k.inflight.sig == sig:
+			call = k.inflight
+			w.mu.Unlock()
+		case k.pending == nil:
+			call = &coalescedWrite{sig: sig, version: version, ctx: ctx, fn: fn, done: make(chan struct{})}
+			k.pending = call
+			w.mu.Unlock()
+		case k.pending.version == version:
+			// Latest wins: the queued write now sends this payload
+			call = k.pending
+			call.sig, call.ctx, call.fn = sig, ctx, fn
+			w.mu.Unlock()
+		default:
+			// A write with another expected version is queued; wait for it
+			// rather than drop either
+			pending := k.pending
+			w.mu.Unlock()
+			select {
+			case <-pending.done:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		select {
+		case <-call.done:
+			if call.sale == nil {
+				return nil, call.err
+			}
+			sale := *call.sale
+			return &sale, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// run performs call, then starts the write queued behind it, if any
+func (w *writeCoalescer) run(key string, call *coalescedWrite) {
+	ctx, cancel := detachContext(call.ctx, coalescedWriteTimeout)
+	call.sale, call.err = call.fn(ctx)
+	cancel()
+	close(call.done)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	k := w.keys[key]
+	next := k.pending
+	k.inflight, k.pending = next, nil
+	if next == nil {
+		delete(w.keys, key)
+		return
+	}
+	go w.run(key, next)
+}
+
+// writeSignature identifies a staged sale write for coalescing
+func writeSignature(id string, sale *StagedSale, version string) (string, error) {
+	payload, err := json.Marshal(sale)
+	if err != nil {
+		return "", err
+	}
+	return id + "\x00" + version + "\x00" + string(payload), nil
+}
@@ -0,0 +1,68 @@
+package gocollect
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWriteCoalescingSurvivesSupersedingCallerCancel(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			<-release
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write([]byte(`{"data":` + strings.TrimSpace(string(body)) + `}`))
+	}, WithWriteCoalescing(true))
+
+	sale := func(title string) *StagedSale {
+		return &StagedSale{
+			PartnerSaleID: "p1",
+			CAM:           "Comics",
+			Title:         title,
+			URL:           "https://example.com/sale/1",
+			Format:        SaleFormatAuction,
+		}
+	}
+	update := func(ctx context.Context, title string) <-chan error {
+		errc := make(chan error, 1)
+		go func() {
+			_, err := c.StagedSales.UpdateStagedSale(ctx, "1", sale(title), "")
+			errc <- err
+		}()
+		return errc
+	}
+
+	first := update(context.Background(), "first")
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	queued := update(context.Background(), "second")
+	time.Sleep(10 * time.Millisecond)
+	latestCtx, cancelLatest := context.WithCancel(context.Background())
+	latest := update(latestCtx, "third")
+	time.Sleep(10 * time.Millisecond)
+
+	cancelLatest()
+	if err := <-latest; err != context.Canceled {
+		t.Errorf("canceled caller error = %v, want context.Canceled", err)
+	}
+	close(release)
+
+	if err := <-first; err != nil {
+		t.Errorf("first update: %v", err)
+	}
+	if err := <-queued; err != nil {
+		t.Errorf("superseded update: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("API calls = %d, want 2", n)
+	}
+}
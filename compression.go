@@ -0,0 +1,90 @@
+package gocollect
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// decoder wraps a compressed response body
+type decoder func(io.Reader) (io.ReadCloser, error)
+
+// contentDecoder associates a content encoding with its decoder
+type contentDecoder struct {
+	encoding string
+	decode   decoder
+}
+
+// decoders holds the supported content encodings, most preferred first.
+// Brotli is added when building with the "brotli" tag.
+var decoders = []contentDecoder{
+	{"gzip", func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }},
+}
+
+// registerDecoder adds a content encoding, preferring it over those already registered
+func registerDecoder(encoding string, decode decoder) {
+	decoders = append([]contentDecoder{{encoding, decode}}, decoders...)
+}
+
+// WithCompression requests compressed responses using the best encoding
+// supported by this build and decompresses them. Gzip is always supported;
+// brotli is supported when the SDK is built with the "brotli" build tag.
+// When disabled, which is the default, the HTTP transport's transparent gzip
+// support applies.
+func WithCompression(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.compression = enabled
+		return nil
+	}
+}
+
+// acceptEncoding returns the Accept-Encoding header value for the supported encodings
+func acceptEncoding() string {
+	encodings := make([]string, len(decoders))
+	for i, d := range decoders {
+		encodings[i] = d.encoding
+	}
+	return strings.Join(encodings, ", ")
+}
+
+// decompressBody replaces resp.Body with a decompressing reader according to
+// its Content-Encoding
+func decompressBody(resp *http.Response) error {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding == "" || encoding == "identity" {
+		return nil
+	}
+
+	for _, d := range decoders {
+		if d.encoding != encoding {
+			continue
+		}
+		r, err := d.decode(resp.Body)
+		if err != nil {
+			return err
+		}
+		resp.Body = &decompressedBody{ReadCloser: r, raw: resp.Body}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+		return nil
+	}
+	return fmt.Errorf("gocollect: unsupported content encoding %q", encoding)
+}
+
+// decompressedBody closes both the decompressor and the underlying body
+type decompressedBody struct {
+	io.ReadCloser
+	raw io.ReadCloser
+}
+
+func (b *decompressedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if rawErr := b.raw.Close(); err == nil {
+		err = rawErr
+	}
+	return err
+}
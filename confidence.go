@@ -0,0 +1,51 @@
+package gocollect
+
+// ConfidenceLevel rates how far the metrics of a period can be trusted
+type ConfidenceLevel string
+
+const (
+	ConfidenceLow    ConfidenceLevel = "low"
+	ConfidenceMedium ConfidenceLevel = "medium"
+	ConfidenceHigh   ConfidenceLevel = "high"
+)
+
+// ConfidenceThresholds sets the minimum sold count and the maximum price
+// spread required for each confidence level. The spread is the difference
+// between the high and low prices divided by the average price.
+type ConfidenceThresholds struct {
+	MediumMinSales  int
+	MediumMaxSpread float64
+	HighMinSales    int
+	HighMaxSpread   float64
+}
+
+// DefaultConfidenceThresholds are the thresholds used by Confidence
+var DefaultConfidenceThresholds = ConfidenceThresholds{
+	MediumMinSales:  5,
+	MediumMaxSpread: 1.0,
+	HighMinSales:    20,
+	HighMaxSpread:   0.5,
+}
+
+// Confidence rates the metrics of period using DefaultConfidenceThresholds
+func (i ItemInsights) Confidence(period MetricsPeriod) ConfidenceLevel {
+	return i.ConfidenceWithThresholds(period, DefaultConfidenceThresholds)
+}
+
+// ConfidenceWithThresholds rates the metrics of period from their sold count
+// and price spread. A period without metrics or sales is rated low.
+func (i ItemInsights) ConfidenceWithThresholds(period MetricsPeriod, t ConfidenceThresholds) ConfidenceLevel {
+	m, ok := i.Metrics[string(period)]
+	if !ok || m.SoldCount == 0 || m.AveragePrice <= 0 {
+		return ConfidenceLow
+	}
+
+	spread := (m.HighPrice - m.LowPrice) / m.AveragePrice
+	switch {
+	case m.SoldCount >= t.HighMinSales && spread <= t.HighMaxSpread:
+		return ConfidenceHigh
+	case m.SoldCount >= t.MediumMinSales && spread <= t.MediumMaxSpread:
+		return ConfidenceMedium
+	}
+	return ConfidenceLow
+}
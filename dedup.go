@@ -0,0 +1,115 @@
+package gocollect
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// insightsDedupTimeout bounds a shared insights call, which no single
+// caller's context can cancel
+const insightsDedupTimeout = time.Minute
+
+// WithInsightsDedup makes concurrent identical insights requests share a
+// single API call. Requests are identical when every parameter matches,
+// including grade, company, label, window and filters, and they are made
+// with the same credentials and the same per-call priority, timeout and
+// cache settings. The shared call is not canceled with the context of any
+// one caller and is bounded to a minute unless WithRequestTimeout sets a
+// timeout; each caller stops waiting when its own context is done. Every
+// caller's WithResponseMetadata is filled from the shared response.
+func WithInsightsDedup(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.insightsDedup = nil
+		if enabled {
+			c.insightsDedup = new(singleflight.Group)
+		}
+		return nil
+	}
+}
+
+// sharedInsights is the outcome of an insights call shared by WithInsightsDedup
+type sharedInsights struct {
+	insights *ItemInsights
+	meta     ResponseMetadata
+	received bool
+}
+
+// doInsights sends an insights request, sharing it with identical requests
+// in flight when deduplication is enabled
+func (s *InsightsService) doInsights(req *http.Request) (*ItemInsights, error) {
+	group := s.client.insightsDedup
+	if group == nil {
+		insights := new(ItemInsights)
+		_, err := s.client.do(req, insights)
+		return insights, err
+	}
+
+	ctx := req.Context()
+	ch := group.DoChan(insightsDedupKey(req), func() (interface{}, error) {
+		return s.doSharedInsights(req)
+	})
+	select {
+	case result := <-ch:
+		shared := result.Val.(*sharedInsights)
+		if m := requestConfigFrom(ctx).metadata; m != nil && shared.received {
+			*m = shared.meta
+			m.Header = shared.meta.Header.Clone()
+		}
+		if !result.Shared {
+			return shared.insights, result.Err
+		}
+		return shared.insights.clone(), result.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// doSharedInsights sends req detached from the cancellation of its context,
+// recording the response metadata for every caller sharing it
+func (s *InsightsService) doSharedInsights(req *http.Request) (*sharedInsights, error) {
+	shared := &sharedInsights{insights: new(ItemInsights)}
+
+	ctx, cancel := detachContext(req.Context(), insightsDedupTimeout)
+	defer cancel()
+	ctx = WithRequestOptions(ctx, WithResponseMetadata(&shared.meta))
+
+	resp, err := s.client.do(req.WithContext(ctx), shared.insights)
+	shared.received = resp != nil
+	return shared, err
+}
+
+// insightsDedupKey identifies the requests that may share a call: the same
+// URL sent with the same credentials and the same per-call settings
+func insightsDedupKey(req *http.Request) string {
+	auth := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	cfg := requestConfigFrom(req.Context())
+	return fmt.Sprintf("%s %s|%s|%d|%s|%t", req.Method, req.URL, hex.EncodeToString(auth[:]),
+		cfg.priority, cfg.timeout, cfg.bypassCache)
+}
+
+// clone returns a copy of i that shares no maps or pointers with it
+func (i *ItemInsights) clone() *ItemInsights {
+	c := *i
+	if i.Metrics != nil {
+		c.Metrics = make(map[string]Metrics, len(i.Metrics))
+		for k, v := range i.Metrics {
+			c.Metrics[k] = v
+		}
+	}
+	if i.FMV != nil {
+		fmv := *i.FMV
+		c.FMV = &fmv
+	}
+	if i.LastCalculatedAt != nil {
+		calculatedAt := *i.LastCalculatedAt
+		c.LastCalculatedAt = &calculatedAt
+	}
+	c.Raw = bytes.Clone(i.Raw)
+	return &c
+}
@@ -0,0 +1,88 @@
+package gocollect
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInsightsDedupSurvivesLeaderCancel(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Write([]byte(`{"item_id":1,"grade":"9.8"}`))
+	}, WithInsightsDedup(true))
+
+	r := InsightsRequest{ItemID: 1, Grade: "9.8"}
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := c.Insights.GetItemInsightsWithOptions(leaderCtx, r); err != context.Canceled {
+			t.Errorf("leader error = %v, want context.Canceled", err)
+		}
+	}()
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var meta ResponseMetadata
+	done := make(chan error, 1)
+	go func() {
+		ctx := WithRequestOptions(context.Background(), WithResponseMetadata(&meta))
+		_, err := c.Insights.GetItemInsightsWithOptions(ctx, r)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancelLeader()
+	wg.Wait()
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("waiter error: %v", err)
+	}
+	if meta.RequestID != "req-1" {
+		t.Errorf("waiter metadata request id = %q, want %q", meta.RequestID, "req-1")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("API calls = %d, want 1", n)
+	}
+}
+
+func TestInsightsDedupKeySeparatesOptions(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	key := func(ctx context.Context) string {
+		req, err := c.newRequest(ctx, "GET", "/api/insights/v1/item/1?grade=9.8", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return insightsDedupKey(req)
+	}
+
+	base := key(context.Background())
+	for name, ctx := range map[string]context.Context{
+		"priority": WithRequestOptions(context.Background(), WithPriority(PriorityHigh)),
+		"timeout":  WithRequestOptions(context.Background(), WithRequestTimeout(time.Second)),
+		"bypass":   WithRequestOptions(context.Background(), WithCacheBypass()),
+	} {
+		if key(ctx) == base {
+			t.Errorf("%s: key matches the default request", name)
+		}
+	}
+
+	other, err := NewClient("other-token", WithBaseURL(c.baseURL.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := other.newRequest(context.Background(), "GET", "/api/insights/v1/item/1?grade=9.8", nil)
+	if insightsDedupKey(req) == base {
+		t.Error("requests with different tokens share a key")
+	}
+}
@@ -0,0 +1,31 @@
+package gocollect
+
+import (
+	"net/http"
+	"time"
+)
+
+// WithDeprecationNotice calls fn for every response marking its endpoint as
+// deprecated with a Deprecation or Sunset header, to plan migrations ahead
+// of removals. path is the request path and sunset the removal date given by
+// the Sunset header, zero if the response only has a Deprecation header. fn
+// is called on every such response and may be called concurrently.
+func WithDeprecationNotice(fn func(path string, sunset time.Time)) ClientOption {
+	return func(c *Client) error {
+		c.deprecationNotice = fn
+		return nil
+	}
+}
+
+// notifyDeprecation reports a response's deprecation headers, if any
+func (c *Client) notifyDeprecation(path string, resp *http.Response) {
+	if c.deprecationNotice == nil {
+		return
+	}
+	deprecation, sunsetHeader := resp.Header.Get("Deprecation"), resp.Header.Get("Sunset")
+	if deprecation == "" && sunsetHeader == "" {
+		return
+	}
+	sunset, _ := http.ParseTime(sunsetHeader)
+	c.deprecationNotice(path, sunset)
+}
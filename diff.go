@@ -0,0 +1,118 @@
+package gocollect
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// FieldChange describes a field whose value differs between two snapshots.
+// Field is the JSON field name; nil values are reported as empty strings.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// serverOwnedStagedSaleFields are the JSON names of the staged sale fields
+// set by the server, which DiffStagedSales ignores
+var serverOwnedStagedSaleFields = map[string]bool{
+	"updated_at":   true,
+	"item":         true,
+	"image_status": true,
+}
+
+// DiffStagedSales reports the fields that changed between two snapshots of a
+// staged sale, in field order. A pointer field going from nil to a value, or
+// back, counts as a change, but a nil slice equals an empty one; times are
+// compared as instants. Fields set by the
+// server, such as UpdatedAt, are ignored.
+func DiffStagedSales(old, new StagedSale) []FieldChange {
+	return diffStructs(reflect.ValueOf(old), reflect.ValueOf(new), serverOwnedStagedSaleFields)
+}
+
+// diffStructs compares the exported fields of two values of the same struct
+// type, skipping the fields named in ignore
+func diffStructs(old, new reflect.Value, ignore map[string]bool) []FieldChange {
+	var changes []FieldChange
+	for _, i := range changedFields(old, new, ignore) {
+		changes = append(changes, FieldChange{
+			Field: jsonFieldName(old.Type().Field(i)),
+			Old:   formatFieldValue(old.Field(i)),
+			New:   formatFieldValue(new.Field(i)),
+		})
+	}
+	return changes
+}
+
+// changedFields returns the indexes of the exported JSON fields that differ
+// between two values of the same struct type, skipping the fields named in
+// ignore
+func changedFields(old, new reflect.Value, ignore map[string]bool) []int {
+	var changed []int
+	t := old.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("json") == "-" || ignore[jsonFieldName(field)] {
+			continue
+		}
+		if !fieldValuesEqual(old.Field(i), new.Field(i)) {
+			changed = append(changed, i)
+		}
+	}
+	return changed
+}
+
+// fieldValuesEqual reports whether two values of a field are equal, comparing
+// times with time.Time.Equal so that the location and monotonic clock
+// reading do not count, and treating nil and empty slices and maps alike
+func fieldValuesEqual(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		a, b = a.Elem(), b.Elem()
+	case reflect.Slice, reflect.Map:
+		if a.Len() == 0 && b.Len() == 0 {
+			return true
+		}
+	}
+	if ta, ok := a.Interface().(time.Time); ok {
+		return ta.Equal(b.Interface().(time.Time))
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// jsonFieldName returns the JSON name of a struct field
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// formatFieldValue renders a field value for a FieldChange
+func formatFieldValue(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return formatTime(value)
+	case []string:
+		return strings.Join(value, ",")
+	case float64:
+		return fmt.Sprintf("%.2f", value)
+	case SearchItem:
+		return fmt.Sprintf("%d", value.ItemID)
+	default:
+		return fmt.Sprint(value)
+	}
+}
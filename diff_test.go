@@ -0,0 +1,46 @@
+package gocollect
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffStagedSalesTimesAndServerFields(t *testing.T) {
+	soldAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	endsAt := time.Now()
+	old := StagedSale{Title: "X-Men #1", SoldAt: soldAt, EndsAt: &endsAt}
+
+	sameEnd := endsAt.Round(0).In(time.FixedZone("EST", -5*3600))
+	updated := soldAt.Add(time.Hour)
+	new := StagedSale{
+		Title:       "X-Men #1",
+		SoldAt:      soldAt.In(time.FixedZone("CET", 3600)),
+		EndsAt:      &sameEnd,
+		UpdatedAt:   &updated,
+		Item:        &SearchItem{ItemID: 1},
+		ImageStatus: []ImageStatus{{URL: "https://example.com/1.jpg", OK: true}},
+	}
+	if changes := DiffStagedSales(old, new); len(changes) != 0 {
+		t.Errorf("DiffStagedSales = %+v, want no changes", changes)
+	}
+
+	new.Title = "X-Men #2"
+	changes := DiffStagedSales(old, new)
+	if len(changes) != 1 || changes[0].Field != "title" {
+		t.Errorf("DiffStagedSales = %+v, want a title change", changes)
+	}
+}
+
+func TestDiffStagedSalesEmptySlices(t *testing.T) {
+	old := StagedSale{Title: "X-Men #1", ImageURLs: []string{}}
+	new := StagedSale{Title: "X-Men #1"}
+	if changes := DiffStagedSales(old, new); len(changes) != 0 {
+		t.Errorf("DiffStagedSales = %+v, want no changes", changes)
+	}
+
+	new.ImageURLs = []string{"https://example.com/1.jpg"}
+	changes := DiffStagedSales(old, new)
+	if len(changes) != 1 || changes[0].Field != "image_urls" {
+		t.Errorf("DiffStagedSales = %+v, want an image_urls change", changes)
+	}
+}
@@ -0,0 +1,123 @@
+package gocollect
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithStrictEnums makes decoding fail with an *EnumError when a response
+// holds an enum value the SDK does not know, such as a new SaleFormat or
+// certification company, instead of passing it through as a plain string.
+// It is off by default.
+func WithStrictEnums(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.strictEnums = enabled
+		return nil
+	}
+}
+
+// EnumError is returned when WithStrictEnums is enabled and a response
+// field holds a value outside its known set
+type EnumError struct {
+	// Field is the path of the field in the response, e.g. "data[2].format"
+	Field string
+	Value string
+}
+
+func (e *EnumError) Error() string {
+	return fmt.Sprintf("gocollect: response field %q has unknown value %q", e.Field, e.Value)
+}
+
+var (
+	saleFormatType           = reflect.TypeOf(SaleFormat(""))
+	certificationCompanyType = reflect.TypeOf(CertificationCompany(""))
+	gradingLabelType         = reflect.TypeOf(GradingLabel(""))
+	gradeQualifierType       = reflect.TypeOf(GradeQualifier(""))
+	soldExampleStatusType    = reflect.TypeOf(SoldExampleStatus(""))
+)
+
+// knownEnumValue reports whether field is an enum and, if so, whether value
+// is one of its known values. Empty values are always accepted.
+func knownEnumValue(field reflect.StructField, value string) (isEnum, known bool) {
+	switch {
+	case field.Type == saleFormatType:
+		return true, value == "" || SaleFormat(value) == SaleFormatAuction || SaleFormat(value) == SaleFormatFixedPrice
+	case field.Type == certificationCompanyType:
+		return true, value == "" || isKnownCertificationCompany(CertificationCompany(value))
+	case field.Type == gradingLabelType:
+		return true, value == "" || isKnownGradingLabel(GradingLabel(value))
+	case field.Type == gradeQualifierType:
+		return true, GradeQualifier(value).validate() == nil
+	case field.Type == soldExampleStatusType:
+		switch SoldExampleStatus(value) {
+		case "", SoldExampleStatusActive, SoldExampleStatusDisputed, SoldExampleStatusRetracted:
+			return true, true
+		}
+		return true, false
+	}
+	return false, false
+}
+
+// isKnownGradingLabel reports whether label is one of the Label constants
+func isKnownGradingLabel(label GradingLabel) bool {
+	switch label {
+	case LabelUniversal, LabelSignatureSeries, LabelSignature, LabelVerifiedSignature,
+		LabelQualified, LabelRestored, LabelConserved, LabelPedigree:
+		return true
+	}
+	return false
+}
+
+// checkEnums returns an *EnumError for the first enum field of the decoded
+// value v holding an unknown value
+func checkEnums(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			return checkEnums(v.Elem(), path)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := checkEnums(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := checkEnums(iter.Value(), fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			fieldPath := name
+			if path != "" {
+				fieldPath = path + "." + name
+			}
+			fv := v.Field(i)
+			if isEnum, known := knownEnumValue(field, fv.String()); isEnum {
+				if !known {
+					return &EnumError{Field: fieldPath, Value: fv.String()}
+				}
+				continue
+			}
+			if err := checkEnums(fv, fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package gocollect
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCheckEnums(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		wantField string
+		wantValue string
+	}{
+		{name: "known values", value: &SoldExample{Format: SaleFormatAuction, CertificationCompany: "CGC", Status: SoldExampleStatusActive}},
+		{name: "sale format", value: &[]SoldExample{{}, {Format: "lot"}}, wantField: "[1].format", wantValue: "lot"},
+		{name: "certification company", value: &StagedSale{CertificationCompany: "XYZ"}, wantField: "certification_company", wantValue: "XYZ"},
+		{name: "sold example status", value: &SoldExample{Status: "archived"}, wantField: "status", wantValue: "archived"},
+		{name: "insights label", value: &ItemInsights{Label: "Green"}, wantField: "label", wantValue: "Green"},
+		{name: "known insights label", value: &ItemInsights{Label: LabelUniversal}},
+		{name: "free text label", value: &FMVChangedEvent{Label: "Green"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkEnums(reflect.ValueOf(tt.value), "")
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("checkEnums = %v, want nil", err)
+				}
+				return
+			}
+			var enumErr *EnumError
+			if !errors.As(err, &enumErr) {
+				t.Fatalf("checkEnums = %v, want *EnumError", err)
+			}
+			if enumErr.Field != tt.wantField || enumErr.Value != tt.wantValue {
+				t.Errorf("EnumError = %+v, want field %q value %q", enumErr, tt.wantField, tt.wantValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package gocollect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxErrorBodySize bounds how much of an error response is read for its message
+const maxErrorBodySize = 64 << 10
+
+var (
+	// ErrNotFound is matched by errors.Is when the requested resource does not exist
+	ErrNotFound = errors.New("gocollect: resource not found")
+
+	// ErrUnauthorized is matched by errors.Is when the API token is missing or invalid
+	ErrUnauthorized = errors.New("gocollect: unauthorized")
+
+	// ErrConflict is matched by errors.Is when a write conflicts with the
+	// resource's current state, e.g. a failed If-Match precondition
+	ErrConflict = errors.New("gocollect: conflict")
+
+	// ErrCircuitOpen is returned without sending the request while the circuit
+	// breaker configured by WithCircuitBreaker is open
+	ErrCircuitOpen = errors.New("gocollect: circuit breaker is open")
+
+	// ErrNoDeadline is returned when WithRequireDeadline is set and a request's
+	// context has no deadline
+	ErrNoDeadline = errors.New("gocollect: request context has no deadline")
+
+	// ErrEmptyToken is returned by NewClient when the token is empty and
+	// neither WithTokenSource nor WithoutAuth is used
+	ErrEmptyToken = errors.New("gocollect: API token is empty")
+
+	// ErrRequestBodyTooLarge is matched by errors.Is when a request body
+	// exceeds the size set with WithMaxRequestBodySize
+	ErrRequestBodyTooLarge = errors.New("gocollect: request body too large")
+
+	// ErrRateLimited is matched by errors.Is when the API responds 429 Too
+	// Many Requests, including when a retry is abandoned because the server
+	// asked to wait longer than the WithRetryAfterCeiling ceiling
+	ErrRateLimited = errors.New("gocollect: rate limited")
+)
+
+// APIError is returned when the API responds with an error status code
+type APIError struct {
+	StatusCode int
+	Message    string
+	// RequestID is the server's identifier for the request, to quote to
+	// GoCollect support; empty if the response did not carry one
+	RequestID string
+	// Body holds the start of the response body, up to 64 KiB
+	Body     []byte
+	Response *http.Response
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("API request failed with status code: %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("API request failed with status code: %d", e.StatusCode)
+}
+
+// Is maps the error's status code to the package's sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || e.StatusCode == http.StatusPreconditionFailed
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// MaintenanceError is returned when the API is unavailable for scheduled
+// maintenance. It wraps the underlying *APIError.
+type MaintenanceError struct {
+	// RetryAt is when the maintenance window is expected to end
+	RetryAt time.Time
+	Message string
+	Err     *APIError
+}
+
+func (e *MaintenanceError) Error() string {
+	return fmt.Sprintf("API under maintenance until %s: %s", e.RetryAt.Format(time.RFC3339), e.Err)
+}
+
+func (e *MaintenanceError) Unwrap() error {
+	return e.Err
+}
+
+// WithErrorMapper sets fn to build the error returned for a response with an
+// error status code, e.g. to map a gateway's error envelope to the package's
+// sentinel errors. fn is given the whole response body, which has also been
+// buffered so resp.Body can be read again. If fn returns nil the default
+// error is used; fn may also call DefaultErrorMapper itself.
+func WithErrorMapper(fn func(resp *http.Response, body []byte) error) ClientOption {
+	return func(c *Client) error {
+		c.errorMapper = fn
+		return nil
+	}
+}
+
+// responseError builds the error for a response with an error status code
+func (c *Client) responseError(resp *http.Response) error {
+	if c.errorMapper == nil {
+		return newResponseError(resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	mapped := c.errorMapper(resp, body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if mapped != nil {
+		return mapped
+	}
+	return DefaultErrorMapper(resp, body)
+}
+
+// newResponseError builds the default error for a response with an error
+// status code, reading up to maxErrorBodySize of its body
+func newResponseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return DefaultErrorMapper(resp, body)
+}
+
+// DefaultErrorMapper builds the SDK's standard error for a response with an
+// error status code: an *APIError, or a *MaintenanceError for a 503 carrying
+// the end of a maintenance window
+func DefaultErrorMapper(resp *http.Response, data []byte) error {
+	if len(data) > maxErrorBodySize {
+		data = data[:maxErrorBodySize]
+	}
+	apiErr := &APIError{StatusCode: resp.StatusCode, RequestID: requestID(resp.Header), Body: data, Response: resp}
+
+	var body struct {
+		Message string     `json:"message"`
+		RetryAt *time.Time `json:"retry_at"`
+	}
+	if json.Unmarshal(apiErr.Body, &body) == nil {
+		apiErr.Message = body.Message
+	}
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		return apiErr
+	}
+	retryAt, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	if body.RetryAt != nil {
+		retryAt, ok = *body.RetryAt, true
+	}
+	if !ok {
+		return apiErr
+	}
+	return &MaintenanceError{RetryAt: retryAt, Message: body.Message, Err: apiErr}
+}
+
+// parseRetryAfter parses a Retry-After header given as either a number of
+// seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
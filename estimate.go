@@ -0,0 +1,130 @@
+package gocollect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ErrInsufficientComps is returned by EstimateFMV when too few sales remain
+// to produce an estimate
+var ErrInsufficientComps = errors.New("gocollect: not enough comparable sales to estimate FMV")
+
+// defaultTrimFraction is the TrimFraction used when none is set
+const defaultTrimFraction = 0.1
+
+// EstimateMethod selects how EstimateFMV aggregates sale prices
+type EstimateMethod int
+
+const (
+	// EstimateMedian uses the median sale price
+	EstimateMedian EstimateMethod = iota
+	// EstimateTrimmedMean averages prices after dropping the TrimFraction
+	// highest and lowest
+	EstimateTrimmedMean
+)
+
+// EstimateOptions configures EstimateFMV. The zero value estimates the median
+// of all sales, requiring at least 3.
+type EstimateOptions struct {
+	Method EstimateMethod
+	// TrimFraction is the fraction of sales dropped from each end for
+	// EstimateTrimmedMean, from 0 to 0.5 exclusive; nil defaults to 0.1, and
+	// a fraction of 0 averages every sale
+	TrimFraction *float64
+	// MaxAge ignores sales older than this, relative to Now; zero keeps all
+	MaxAge time.Duration
+	// Now is the reference time for MaxAge; defaults to time.Now()
+	Now time.Time
+	// OutlierThreshold rejects sales further from the median than this many
+	// median absolute deviations; zero disables outlier rejection
+	OutlierThreshold float64
+	// MinComps is the number of sales required after filtering; defaults to 3
+	MinComps int
+}
+
+// EstimateFMV estimates an item's fair market value from its recent sold
+// examples, for items the API has no FMV for
+func EstimateFMV(examples []SoldExample, opts EstimateOptions) (float64, error) {
+	trimFraction := defaultTrimFraction
+	if opts.TrimFraction != nil {
+		trimFraction = *opts.TrimFraction
+	}
+	if trimFraction < 0 || trimFraction >= 0.5 {
+		return 0, fmt.Errorf("gocollect: trim fraction must be in [0, 0.5), got %g", trimFraction)
+	}
+	if opts.MinComps <= 0 {
+		opts.MinComps = 3
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	prices := make([]float64, 0, len(examples))
+	for _, e := range examples {
+		if e.SoldPrice <= 0 {
+			continue
+		}
+		if opts.MaxAge > 0 && opts.Now.Sub(e.SoldAt) > opts.MaxAge {
+			continue
+		}
+		prices = append(prices, e.SoldPrice)
+	}
+	sort.Float64s(prices)
+
+	if opts.OutlierThreshold > 0 && len(prices) > 0 {
+		prices = rejectOutliers(prices, opts.OutlierThreshold)
+	}
+	if len(prices) < opts.MinComps {
+		return 0, ErrInsufficientComps
+	}
+
+	switch opts.Method {
+	case EstimateMedian:
+		return median(prices), nil
+	case EstimateTrimmedMean:
+		trim := int(float64(len(prices)) * trimFraction)
+		kept := prices[trim : len(prices)-trim]
+		var sum float64
+		for _, p := range kept {
+			sum += p
+		}
+		return sum / float64(len(kept)), nil
+	default:
+		return 0, fmt.Errorf("gocollect: unknown estimate method %d", opts.Method)
+	}
+}
+
+// rejectOutliers drops sorted prices further than threshold median absolute
+// deviations from the median
+func rejectOutliers(sorted []float64, threshold float64) []float64 {
+	m := median(sorted)
+	deviations := make([]float64, len(sorted))
+	for i, p := range sorted {
+		deviations[i] = math.Abs(p - m)
+	}
+	sort.Float64s(deviations)
+	mad := median(deviations)
+	if mad == 0 {
+		return sorted
+	}
+
+	kept := sorted[:0:0]
+	for _, p := range sorted {
+		if math.Abs(p-m)/mad <= threshold {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// median returns the median of sorted values
+func median(sorted []float64) float64 {
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,100 @@
+package gocollect
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// ETagCache stores responses by key along with their ETag, for conditional
+// requests. Implementations must be safe for concurrent use.
+type ETagCache interface {
+	Get(key string) (etag string, body []byte, ok bool)
+	Set(key string, etag string, body []byte)
+}
+
+// WithETagCache makes single-resource gets that support it, such as
+// GetSoldExampleContext, revalidate cached copies with If-None-Match and
+// reuse them when the API responds 304 Not Modified. Use WithCacheBypass to
+// force a full fetch for one call.
+func WithETagCache(cache ETagCache) ClientOption {
+	return func(c *Client) error {
+		c.etagCache = cache
+		return nil
+	}
+}
+
+// WithCacheBypass skips the ETag cache for a call: the response is fetched
+// in full, and still stored in the cache for later calls
+func WithCacheBypass() RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.bypassCache = true
+	}
+}
+
+// NewMemoryETagCache returns an in-memory ETagCache holding up to maxEntries
+// responses, evicting the oldest stored first
+func NewMemoryETagCache(maxEntries int) ETagCache {
+	return &memoryETagCache{max: maxEntries, entries: make(map[string]etagEntry)}
+}
+
+type etagEntry struct {
+	etag string
+	body []byte
+}
+
+type memoryETagCache struct {
+	mu      sync.Mutex
+	max     int
+	entries map[string]etagEntry
+	order   []string
+}
+
+func (m *memoryETagCache) Get(key string) (string, []byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	return e.etag, e.body, ok
+}
+
+func (m *memoryETagCache) Set(key string, etag string, body []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.entries[key]; !ok {
+		m.order = append(m.order, key)
+	}
+	m.entries[key] = etagEntry{etag: etag, body: body}
+	for m.max > 0 && len(m.order) > m.max {
+		delete(m.entries, m.order[0])
+		m.order = m.order[1:]
+	}
+}
+
+// getCached sends a GET request for a single resource, revalidating the
+// cached copy of v if the client has an ETag cache. v is decoded from the
+// cache on 304 Not Modified.
+func (c *Client) getCached(req *http.Request, v interface{}) (*http.Response, error) {
+	if c.etagCache == nil {
+		return c.do(req, v)
+	}
+
+	key := req.URL.String()
+	etag, cached, ok := c.etagCache.Get(key)
+	if ok && !requestConfigFrom(req.Context()).bypassCache {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.do(req, v)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		return resp, json.Unmarshal(cached, v)
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		if body, err := json.Marshal(v); err == nil {
+			c.etagCache.Set(key, etag, body)
+		}
+	}
+	return resp, nil
+}
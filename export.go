@@ -0,0 +1,149 @@
+package gocollect
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// soldExampleCSVHeader lists the columns written by WriteSoldExamplesCSV
+var soldExampleCSVHeader = []string{
+	"partner_sale_id", "cam", "title", "gocollect_item_id", "certification_company",
+	"certification_key", "listed_price", "listed_at", "sold_price", "sold_at",
+	"url", "format", "auction_name", "bid_count", "status",
+}
+
+// WriteSoldExamplesCSV writes sold examples to w as CSV with a header row
+func WriteSoldExamplesCSV(w io.Writer, examples []SoldExample) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(soldExampleCSVHeader); err != nil {
+		return err
+	}
+	return writeSoldExampleRows(cw, examples)
+}
+
+// writeSoldExampleRows writes one CSV row per sold example and flushes
+func writeSoldExampleRows(cw *csv.Writer, examples []SoldExample) error {
+	for _, e := range examples {
+		row := []string{
+			e.PartnerSaleID,
+			e.CAM,
+			e.Title,
+			formatOptionalInt(e.GocollectItemID),
+			string(e.CertificationCompany),
+			formatOptionalString(e.CertificationKey),
+			formatOptionalFloat(e.ListedPrice),
+			formatTime(e.ListedAt),
+			strconv.FormatFloat(e.SoldPrice, 'f', 2, 64),
+			formatTime(e.SoldAt),
+			e.URL,
+			string(e.Format),
+			formatOptionalString(e.AuctionName),
+			formatOptionalInt(e.BidCount),
+			string(e.Status),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportSoldExamplesCSV writes the sold examples matching opts to w as CSV.
+// CSV is requested from the API and streamed to w as is; if the API responds
+// with JSON instead, every page is fetched and converted with
+// WriteSoldExamplesCSV's format. opts.Page is ignored.
+func (s *SoldExamplesService) ExportSoldExamplesCSV(ctx context.Context, opts ListSoldExamplesOptions, w io.Writer) error {
+	opts.Page = 0
+	opts.Next = ""
+	params := s.listSoldExamplesParams(opts)
+	path := listPath(s.client.apiBase(APIResources)+"/sold-examples", params, opts.ListOptions)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/csv, application/json;q=0.5")
+
+	export := &csvExport{client: s.client, w: w}
+	resp, err := s.client.do(req, export)
+	if err != nil {
+		return err
+	}
+	if export.streamed {
+		return nil
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(soldExampleCSVHeader); err != nil {
+		return err
+	}
+	if err := writeSoldExampleRows(cw, export.page.Data); err != nil {
+		return err
+	}
+	for meta := s.client.withLinkHeader(resp, export.page.Meta); meta.HasNextPage(); {
+		opts.nextPage(meta)
+		var examples []SoldExample
+		examples, meta, err = s.ListSoldExamples(ctx, opts)
+		if err != nil {
+			return err
+		}
+		if err := writeSoldExampleRows(cw, examples); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvExport streams a CSV response to w, or decodes a JSON list response
+type csvExport struct {
+	client   *Client
+	w        io.Writer
+	streamed bool
+	page     struct {
+		Data []SoldExample `json:"data"`
+		Meta *ListMeta     `json:"meta"`
+	}
+}
+
+func (e *csvExport) handleResponse(ctx context.Context, resp *http.Response) error {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		e.streamed = true
+		_, err := io.Copy(e.w, resp.Body)
+		return err
+	}
+	return e.client.decodeResponse(ctx, resp, &e.page)
+}
+
+func formatOptionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatOptionalInt(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
+
+func formatOptionalFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', 2, 64)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,44 @@
+package gocollect
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExportSoldExamplesCSVJSONFallback(t *testing.T) {
+	const body = `{"data":[{"partner_sale_id":"1","title":" X-Men #1 ","format":"auction"},{"partner_sale_id":"2","format":"lot"}],"meta":{"current_page":1,"last_page":1}}`
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}
+
+	t.Run("post-processors run", func(t *testing.T) {
+		c := newTestClient(t, handler, WithResultPostProcessor(func(v interface{}) {
+			if examples, ok := v.(*[]SoldExample); ok {
+				for i := range *examples {
+					(*examples)[i].Title = strings.TrimSpace((*examples)[i].Title)
+				}
+			}
+		}))
+		var buf bytes.Buffer
+		if err := c.SoldExamples.ExportSoldExamplesCSV(context.Background(), ListSoldExamplesOptions{}, &buf); err != nil {
+			t.Fatalf("ExportSoldExamplesCSV: %v", err)
+		}
+		if !strings.Contains(buf.String(), "\n1,,X-Men #1,") {
+			t.Errorf("CSV does not hold the processed title:\n%s", buf.String())
+		}
+	})
+
+	t.Run("strict enums apply", func(t *testing.T) {
+		c := newTestClient(t, handler, WithStrictEnums(true))
+		err := c.SoldExamples.ExportSoldExamplesCSV(context.Background(), ListSoldExamplesOptions{}, new(bytes.Buffer))
+		var enumErr *EnumError
+		if !errors.As(err, &enumErr) || enumErr.Field != "data[1].format" {
+			t.Errorf("ExportSoldExamplesCSV = %v, want an *EnumError for data[1].format", err)
+		}
+	})
+}
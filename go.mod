@@ -1,3 +1,10 @@
 module github.com/ZacxDev/go-gocollect-sdk
 
 go 1.22.10
+
+require (
+	github.com/andybalholm/brotli v1.2.0
+	go.opentelemetry.io/otel v1.32.0
+	go.opentelemetry.io/otel/metric v1.32.0
+	golang.org/x/sync v0.9.0
+)
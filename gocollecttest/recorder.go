@@ -0,0 +1,223 @@
+// Package gocollecttest provides helpers for testing code that uses the
+// GoCollect SDK.
+package gocollecttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Mode selects whether a Recorder talks to the API or replays a recording
+type Mode int
+
+const (
+	// ModeAuto replays the recording if its file exists and records
+	// otherwise
+	ModeAuto Mode = iota
+	// ModeRecord sends every request to the API and records it, replacing
+	// any existing recording
+	ModeRecord
+	// ModeReplay serves every request from the recording and never touches
+	// the network
+	ModeReplay
+)
+
+// redactedHeaders are replaced in recordings so credentials are not
+// written to disk
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Recorder is an http.RoundTripper that records API interactions to a file
+// and replays them later, for fast and deterministic integration tests:
+//
+//	rec, err := gocollecttest.NewRecorder("testdata/search.json", gocollecttest.ModeAuto)
+//	client, err := gocollect.NewClient(token, gocollect.WithHTTPClient(rec.Client()))
+//
+// Recorded requests are matched on method and URL, in order, so a request
+// made twice replays the two recorded responses in turn. Bodies are recorded
+// as text, so do not use WithCompression while recording.
+type Recorder struct {
+	// Transport sends requests while recording; http.DefaultTransport if nil
+	Transport http.RoundTripper
+
+	path      string
+	replaying bool
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// Interaction is one recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the recorded form of a request
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the recorded form of a response
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// NewRecorder returns a Recorder using the recording at path. In ModeReplay
+// and in ModeAuto when the file exists, the recording is loaded now.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist) && mode == ModeAuto:
+		return r, nil
+	default:
+		return nil, err
+	}
+
+	var recording struct {
+		Interactions []Interaction `json:"interactions"`
+	}
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return nil, fmt.Errorf("gocollecttest: reading %s: %w", path, err)
+	}
+	r.replaying = true
+	r.interactions = recording.Interactions
+	r.used = make([]bool, len(recording.Interactions))
+	return r, nil
+}
+
+// Client returns an HTTP client using the recorder, for gocollect.WithHTTPClient
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip records or replays a request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.replaying {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != req.URL.String() {
+			continue
+		}
+		r.used[i] = true
+		recorded := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("gocollecttest: no recorded response for %s %s", req.Method, req.URL)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	// The body is read for the recording, so a clone carrying a copy of it
+	// is sent on, leaving req unmodified as RoundTrip requires
+	out := req
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		out = req.Clone(req.Context())
+		out.Body = io.NopCloser(bytes.NewReader(reqBody))
+		out.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(reqBody)), nil
+		}
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	resp.Request = req
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: redact(req.Header),
+			Body:   string(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redact(resp.Header),
+			Body:       string(respBody),
+		},
+	})
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes the recording to its file. r.mu must be held.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(struct {
+		Interactions []Interaction `json:"interactions"`
+	}{r.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, data, 0o644)
+}
+
+// redact returns a copy of h with credentials replaced
+func redact(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range redactedHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, "REDACTED")
+		}
+	}
+	return h
+}
@@ -0,0 +1,133 @@
+package gocollecttest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	gocollect "github.com/ZacxDev/go-gocollect-sdk"
+	"github.com/ZacxDev/go-gocollect-sdk/gocollecttest"
+)
+
+// newWatchlistServer serves the watchlist endpoints from memory
+func newWatchlistServer(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	watched := make(map[int]bool)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/resources/v1/watchlist", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPost:
+			var body struct {
+				ItemID int `json:"item_id"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.ItemID == 0 {
+				http.Error(w, `{"message":"invalid item_id"}`, http.StatusUnprocessableEntity)
+				return
+			}
+			watched[body.ItemID] = true
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			ids := make([]int, 0, len(watched))
+			for id := range watched {
+				ids = append(ids, id)
+			}
+			sort.Ints(ids)
+			items := make([]string, len(ids))
+			for i, id := range ids {
+				items[i] = fmt.Sprintf(`{"item_id":%d,"slug":"item-%d"}`, id, id)
+			}
+			fmt.Fprintf(w, `{"data":[%s],"meta":{"current_page":1,"last_page":1}}`, strings.Join(items, ","))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/resources/v1/watchlist/", func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/resources/v1/watchlist/"))
+		if err != nil || r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if !watched[id] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(watched, id)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// exerciseWatchlist adds two items, removes one and checks the listing
+func exerciseWatchlist(t *testing.T, client *gocollect.Client) {
+	t.Helper()
+	ctx := context.Background()
+
+	for _, id := range []int{223124, 5} {
+		if err := client.Watchlist.AddItem(ctx, id); err != nil {
+			t.Fatalf("AddItem(%d): %v", id, err)
+		}
+	}
+	if err := client.Watchlist.RemoveItem(ctx, 5); err != nil {
+		t.Fatalf("RemoveItem: %v", err)
+	}
+
+	items, meta, err := client.Watchlist.ListItems(ctx, gocollect.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListItems: %v", err)
+	}
+	if len(items) != 1 || items[0].ItemID != 223124 || items[0].Slug != "item-223124" {
+		t.Errorf("ListItems = %+v, want item 223124 only", items)
+	}
+	if meta.HasNextPage() {
+		t.Error("ListItems reports a next page")
+	}
+
+	err = client.Watchlist.RemoveItem(ctx, 5)
+	if !errors.Is(err, gocollect.ErrNotFound) {
+		t.Errorf("RemoveItem of an unwatched item = %v, want ErrNotFound", err)
+	}
+}
+
+func TestWatchlistRecordAndReplay(t *testing.T) {
+	srv := newWatchlistServer(t)
+	path := filepath.Join(t.TempDir(), "watchlist.json")
+
+	rec, err := gocollecttest.NewRecorder(path, gocollecttest.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := gocollect.NewClient("test-token", gocollect.WithBaseURL(srv.URL), gocollect.WithHTTPClient(rec.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exerciseWatchlist(t, client)
+
+	// The replay serves the recorded responses with the server gone
+	srv.Close()
+	replay, err := gocollecttest.NewRecorder(path, gocollecttest.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err = gocollect.NewClient("test-token", gocollect.WithBaseURL(srv.URL), gocollect.WithHTTPClient(replay.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exerciseWatchlist(t, client)
+}
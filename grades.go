@@ -0,0 +1,94 @@
+package gocollect
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Grade is a grade on the common 0.5 to 10.0 comic grading scale
+type Grade float64
+
+// String formats the grade with one decimal, e.g. "9.8"
+func (g Grade) String() string {
+	return strconv.FormatFloat(float64(g), 'f', 1, 64)
+}
+
+// comicGradeNames maps the grade designations shared by CGC, CBCS and PGX to
+// the numeric scale
+var comicGradeNames = map[string]Grade{
+	"GEMMINT":       10.0,
+	"GM":            10.0,
+	"MINT":          9.9,
+	"MT":            9.9,
+	"NM/M":          9.8,
+	"NEARMINT/MINT": 9.8,
+	"NM+":           9.6,
+	"NEARMINT+":     9.6,
+	"NM":            9.4,
+	"NEARMINT":      9.4,
+	"NM-":           9.2,
+	"NEARMINT-":     9.2,
+	"VF/NM":         9.0,
+	"VF+":           8.5,
+	"VF":            8.0,
+	"VERYFINE":      8.0,
+	"VF-":           7.5,
+	"FN/VF":         7.0,
+	"FN+":           6.5,
+	"FN":            6.0,
+	"FINE":          6.0,
+	"FN-":           5.5,
+	"VG/FN":         5.0,
+	"VG+":           4.5,
+	"VG":            4.0,
+	"VERYGOOD":      4.0,
+	"VG-":           3.5,
+	"GD/VG":         3.0,
+	"GD+":           2.5,
+	"GD":            2.0,
+	"GOOD":          2.0,
+	"GD-":           1.8,
+	"FR/GD":         1.5,
+	"FR":            1.0,
+	"FAIR":          1.0,
+	"PR":            0.5,
+	"POOR":          0.5,
+}
+
+// comicGradeScale lists the numeric grades that exist on the scale
+var comicGradeScale = func() map[Grade]bool {
+	scale := make(map[Grade]bool, len(comicGradeNames))
+	for _, g := range comicGradeNames {
+		scale[g] = true
+	}
+	return scale
+}()
+
+// gradeNumberPattern finds the numeric part of a grade label
+var gradeNumberPattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+// CanonicalGrade converts a grade as written by a certification company,
+// such as "9.8", "NM/M 9.8" or "Gem Mint 10", to the common numeric scale.
+// CGC, CBCS and PGX share the same scale and grade names, so the company
+// only needs to be one of them.
+func CanonicalGrade(company CertificationCompany, raw string) (Grade, error) {
+	if !isKnownCertificationCompany(company) {
+		return 0, fmt.Errorf("gocollect: unknown certification company %q", company)
+	}
+
+	if number := gradeNumberPattern.FindString(raw); number != "" {
+		value, err := strconv.ParseFloat(number, 64)
+		if err == nil && comicGradeScale[Grade(value)] {
+			return Grade(value), nil
+		}
+		return 0, fmt.Errorf("gocollect: invalid %s grade %q", company, raw)
+	}
+
+	name := strings.ToUpper(strings.Join(strings.Fields(raw), ""))
+	if grade, ok := comicGradeNames[name]; ok {
+		return grade, nil
+	}
+	return 0, fmt.Errorf("gocollect: unknown %s grade %q", company, raw)
+}
@@ -0,0 +1,283 @@
+package gocollect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// dateLayout is the format the API uses for calendar dates
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date encoded as YYYY-MM-DD
+type Date struct {
+	time.Time
+}
+
+// UnmarshalJSON parses a YYYY-MM-DD date
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		d.Time = time.Time{}
+		return nil
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return err
+	}
+	d.Time = t
+	return nil
+}
+
+// MarshalJSON encodes the date as YYYY-MM-DD
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.Format(dateLayout) + `"`), nil
+}
+
+// FMVPoint represents an item's valuation on a given date
+type FMVPoint struct {
+	Date         Date     `json:"date"`
+	FMV          *float64 `json:"fmv"`
+	AveragePrice *float64 `json:"average_price"`
+}
+
+// FMVHistoryOptions represents the parameters for fetching FMV history
+type FMVHistoryOptions struct {
+	Company string
+	Label   GradingLabel
+	// Qualifier restricts the history to one grading tier; unset includes all
+	Qualifier GradeQualifier
+	From      time.Time
+	To        time.Time
+	// ChunkSize splits the range into windows of this length, fetched one
+	// request at a time, to keep responses small. It requires From; To
+	// defaults to today. Zero fetches the whole range in one request.
+	ChunkSize time.Duration
+}
+
+// GetFMVHistory retrieves an item's FMV over time, oldest first. With
+// opts.ChunkSize set, the chunks are fetched in turn and stitched together.
+func (s *InsightsService) GetFMVHistory(ctx context.Context, itemID int, grade string, opts FMVHistoryOptions) ([]FMVPoint, error) {
+	if opts.ChunkSize <= 0 || opts.From.IsZero() {
+		return s.getFMVHistory(ctx, itemID, grade, opts)
+	}
+
+	var history []FMVPoint
+	it := s.FMVHistory(ctx, itemID, grade, opts)
+	for it.Next() {
+		history = append(history, it.Point())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// getFMVHistory fetches the history for the range of opts in one request
+func (s *InsightsService) getFMVHistory(ctx context.Context, itemID int, grade string, opts FMVHistoryOptions) ([]FMVPoint, error) {
+	if err := s.client.validateCompanyLabel(opts.Company, opts.Label); err != nil {
+		return nil, err
+	}
+	if err := opts.Qualifier.validate(); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("grade", grade)
+	if opts.Company != "" {
+		params.Add("company", opts.Company)
+	}
+	if opts.Label != "" {
+		params.Add("label", string(opts.Label))
+	}
+	if opts.Qualifier != "" {
+		params.Add("qualifier", string(opts.Qualifier))
+	}
+	if !opts.From.IsZero() {
+		params.Add("from", opts.From.Format(dateLayout))
+	}
+	if !opts.To.IsZero() {
+		params.Add("to", opts.To.Format(dateLayout))
+	}
+
+	path := fmt.Sprintf("%s/item/%d/history?%s", s.client.apiBase(APIInsights), itemID, params.Encode())
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []FMVPoint
+	_, err = s.client.do(req, &history)
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// defaultFMVHistoryChunk is the window length used by FMVHistory when
+// opts.ChunkSize is not set
+const defaultFMVHistoryChunk = 365 * 24 * time.Hour
+
+// FMVHistoryIterator walks an item's FMV history oldest first, fetching one
+// date window at a time. Points repeated at the boundary of two windows are
+// returned once.
+//
+//	it := client.Insights.FMVHistory(ctx, itemID, "9.8", opts)
+//	for it.Next() {
+//		point := it.Point()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type FMVHistoryIterator struct {
+	ctx     context.Context
+	service *InsightsService
+	itemID  int
+	grade   string
+	opts    FMVHistoryOptions
+
+	next  time.Time
+	end   time.Time
+	last  time.Time
+	buf   []FMVPoint
+	point FMVPoint
+	err   error
+}
+
+// FMVHistory returns an iterator over an item's FMV history between
+// opts.From and opts.To in windows of opts.ChunkSize, defaulting to a year.
+// Without opts.From the whole history is fetched in one request.
+func (s *InsightsService) FMVHistory(ctx context.Context, itemID int, grade string, opts FMVHistoryOptions) *FMVHistoryIterator {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = defaultFMVHistoryChunk
+	}
+	it := &FMVHistoryIterator{ctx: ctx, service: s, itemID: itemID, grade: grade, opts: opts}
+	it.next = opts.From
+	it.end = opts.To
+	if it.end.IsZero() {
+		it.end = time.Now()
+	}
+	return it
+}
+
+// Next advances to the next point, returning false when the history is
+// exhausted or an error occurred
+func (it *FMVHistoryIterator) Next() bool {
+	for len(it.buf) == 0 {
+		if it.err != nil || it.next.After(it.end) {
+			return false
+		}
+		it.err = it.fetch()
+	}
+	it.point, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Point returns the current point
+func (it *FMVHistoryIterator) Point() FMVPoint {
+	return it.point
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *FMVHistoryIterator) Err() error {
+	return it.err
+}
+
+// fetch requests the next window and buffers the points after the last one
+// already buffered
+func (it *FMVHistoryIterator) fetch() error {
+	opts := it.opts
+	if !opts.From.IsZero() {
+		opts.From = it.next
+		opts.To = it.next.Add(it.opts.ChunkSize)
+		if opts.To.After(it.end) {
+			opts.To = it.end
+		}
+	}
+
+	points, err := it.service.getFMVHistory(it.ctx, it.itemID, it.grade, opts)
+	if err != nil {
+		return err
+	}
+	for _, p := range points {
+		if it.last.IsZero() || p.Date.After(it.last) {
+			it.buf = append(it.buf, p)
+			it.last = p.Date.Time
+		}
+	}
+	if opts.From.IsZero() {
+		it.next = it.end.Add(time.Nanosecond)
+	} else {
+		it.next = opts.To.AddDate(0, 0, 1)
+	}
+	return nil
+}
+
+// InsightsComparison describes how an item's valuation changed between two
+// dates. A nil field means the data needed to compute it was unavailable.
+type InsightsComparison struct {
+	From time.Time
+	To   time.Time
+
+	FromFMV      *float64
+	ToFMV        *float64
+	FMVChange    *float64
+	FMVChangePct *float64
+
+	FromAveragePrice      *float64
+	ToAveragePrice        *float64
+	AveragePriceChange    *float64
+	AveragePriceChangePct *float64
+}
+
+// CompareItemInsights compares an item's FMV and average price between two
+// dates. Each value is taken from the earliest data point on or after from and
+// the latest on or before to.
+func (s *InsightsService) CompareItemInsights(ctx context.Context, itemID int, grade string, from, to time.Time) (*InsightsComparison, error) {
+	if !from.Before(to) {
+		return nil, errors.New("gocollect: from must be before to")
+	}
+
+	history, err := s.GetFMVHistory(ctx, itemID, grade, FMVHistoryOptions{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+
+	cmp := &InsightsComparison{From: from, To: to}
+	cmp.FromFMV, cmp.ToFMV = historyEndpoints(history, func(p FMVPoint) *float64 { return p.FMV })
+	cmp.FMVChange, cmp.FMVChangePct = change(cmp.FromFMV, cmp.ToFMV)
+	cmp.FromAveragePrice, cmp.ToAveragePrice = historyEndpoints(history, func(p FMVPoint) *float64 { return p.AveragePrice })
+	cmp.AveragePriceChange, cmp.AveragePriceChangePct = change(cmp.FromAveragePrice, cmp.ToAveragePrice)
+	return cmp, nil
+}
+
+// historyEndpoints returns the first and last non-nil values in history
+func historyEndpoints(history []FMVPoint, value func(FMVPoint) *float64) (first, last *float64) {
+	for _, p := range history {
+		if v := value(p); v != nil {
+			if first == nil {
+				first = v
+			}
+			last = v
+		}
+	}
+	return first, last
+}
+
+// change returns the absolute and percent change from a to b
+func change(a, b *float64) (abs, pct *float64) {
+	if a == nil || b == nil {
+		return nil, nil
+	}
+	d := *b - *a
+	abs = &d
+	if *a != 0 {
+		p := d / *a * 100
+		pct = &p
+	}
+	return abs, pct
+}
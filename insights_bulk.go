@@ -0,0 +1,90 @@
+package gocollect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// InsightsResult holds the outcome of one request in a bulk insights call
+type InsightsResult struct {
+	Insights *ItemInsights
+	Err      error
+}
+
+// GetItemInsightsBulk retrieves insights for many items in one POST request,
+// avoiding the URL length limits of query parameters. Results are returned in
+// the order of reqs. If the API does not provide the bulk endpoint, the
+// insights are fetched with concurrent GET requests instead.
+func (s *InsightsService) GetItemInsightsBulk(ctx context.Context, reqs []InsightsRequest) ([]InsightsResult, error) {
+	for _, r := range reqs {
+		if err := r.validate(s.client); err != nil {
+			return nil, err
+		}
+	}
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+
+	if !s.bulkUnsupported.Load() {
+		results, err := s.postItemInsightsBulk(ctx, reqs)
+		if !isEndpointMissing(err) {
+			return results, err
+		}
+		s.bulkUnsupported.Store(true)
+	}
+
+	return s.getItemInsightsConcurrently(ctx, reqs), nil
+}
+
+func (s *InsightsService) postItemInsightsBulk(ctx context.Context, reqs []InsightsRequest) ([]InsightsResult, error) {
+	body := struct {
+		Requests []InsightsRequest `json:"requests"`
+	}{reqs}
+	req, err := s.client.newRequest(markIdempotent(ctx), "POST", s.client.apiBase(APIInsights)+"/items/bulk", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data []*ItemInsights `json:"data"`
+	}
+	if _, err := s.client.do(req, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Data) != len(reqs) {
+		return nil, errors.New("gocollect: bulk insights response does not match the request count")
+	}
+
+	results := make([]InsightsResult, len(reqs))
+	for i, insights := range response.Data {
+		if insights == nil {
+			results[i].Err = ErrNotFound
+			continue
+		}
+		results[i].Insights = insights
+	}
+	return results, nil
+}
+
+func (s *InsightsService) getItemInsightsConcurrently(ctx context.Context, reqs []InsightsRequest) []InsightsResult {
+	results := make([]InsightsResult, len(reqs))
+	forEachConcurrently(ctx, len(reqs), func(i int) {
+		insights, err := s.GetItemInsightsWithOptions(ctx, reqs[i])
+		if err != nil {
+			results[i].Err = err
+			return
+		}
+		results[i].Insights = insights
+	}, func(i int, err error) {
+		results[i].Err = err
+	})
+	return results
+}
+
+// isEndpointMissing reports whether err indicates the endpoint does not exist
+func isEndpointMissing(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,55 @@
+package gocollect
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// requestObservation describes one attempt of an API request, as reported
+// to the instrumentation installed by WithMeterProvider
+type requestObservation struct {
+	Method string
+	// Route is the request path with identifiers replaced by "{id}"
+	Route string
+	// StatusCode is zero when the attempt failed without a response
+	StatusCode int
+	Duration   time.Duration
+}
+
+// versionSegment matches the API version segment of a path, e.g. "v1"
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// routeTemplate replaces the identifiers in an API path with "{id}" so that
+// requests for different records share one route, e.g.
+// "/api/insights/v1/item/123" becomes "/api/insights/v1/item/{id}"
+func routeTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if versionSegment.MatchString(segment) {
+			continue
+		}
+		if strings.ContainsAny(segment, "0123456789") {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// observeAttempt reports an attempt to the installed instrumentation, if any
+func (c *Client) observeAttempt(ctx context.Context, req *http.Request, resp *http.Response, d time.Duration) {
+	if c.observeRequest == nil {
+		return
+	}
+	o := requestObservation{
+		Method:   req.Method,
+		Route:    routeTemplate(req.URL.Path),
+		Duration: d,
+	}
+	if resp != nil {
+		o.StatusCode = resp.StatusCode
+	}
+	c.observeRequest(ctx, o)
+}
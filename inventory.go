@@ -0,0 +1,90 @@
+package gocollect
+
+import (
+	"context"
+	"time"
+)
+
+// InventoryRecentWindow is how far back GetInventorySnapshot looks for
+// recently sold examples
+const InventoryRecentWindow = 7 * 24 * time.Hour
+
+// InventorySnapshot is a seller's live inventory: its active staged sales
+// and the examples it sold within InventoryRecentWindow
+type InventorySnapshot struct {
+	CAM         string
+	ActiveSales []StagedSale
+	RecentSold  []SoldExample
+
+	// ListedValue is the total price of the active sales, using the listed
+	// price for sales without a current price. Sales with neither are counted
+	// in ActiveSales but not in the total.
+	ListedValue float64
+	// SoldValue is the total sold price of RecentSold
+	SoldValue float64
+	TakenAt   time.Time
+}
+
+// ActiveCount returns the number of active staged sales
+func (s *InventorySnapshot) ActiveCount() int {
+	return len(s.ActiveSales)
+}
+
+// RecentSoldCount returns the number of recently sold examples
+func (s *InventorySnapshot) RecentSoldCount() int {
+	return len(s.RecentSold)
+}
+
+// GetInventorySnapshot pages through a seller's active staged sales and
+// recent sold examples and summarizes them
+func (c *Client) GetInventorySnapshot(ctx context.Context, cam string) (*InventorySnapshot, error) {
+	snapshot := &InventorySnapshot{CAM: cam, TakenAt: time.Now()}
+
+	active := true
+	salesOpts := ListStagedSalesOptions{CAM: cam, IsActive: &active}
+	for {
+		sales, meta, err := c.StagedSales.ListStagedSales(ctx, salesOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, sale := range sales {
+			if !sale.IsActive {
+				continue
+			}
+			snapshot.ActiveSales = append(snapshot.ActiveSales, sale)
+			if sale.Price != nil {
+				snapshot.ListedValue += *sale.Price
+			} else if sale.ListedPrice != nil {
+				snapshot.ListedValue += *sale.ListedPrice
+			}
+		}
+		if !meta.HasNextPage() {
+			break
+		}
+		salesOpts.nextPage(meta)
+	}
+
+	since := snapshot.TakenAt.Add(-InventoryRecentWindow)
+	soldOpts := ListSoldExamplesOptions{CAM: cam, Sort: "-sold_at"}
+	for {
+		examples, meta, err := c.SoldExamples.ListSoldExamples(ctx, soldOpts)
+		if err != nil {
+			return nil, err
+		}
+		older := false
+		for _, example := range examples {
+			if example.SoldAt.Before(since) {
+				older = true
+				break
+			}
+			snapshot.RecentSold = append(snapshot.RecentSold, example)
+			snapshot.SoldValue += example.SoldPrice
+		}
+		if older || !meta.HasNextPage() {
+			break
+		}
+		soldOpts.nextPage(meta)
+	}
+
+	return snapshot, nil
+}
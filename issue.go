@@ -0,0 +1,98 @@
+package gocollect
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// vulgarFractions maps the fraction characters used in issue numbers to their values
+var vulgarFractions = map[rune]float64{
+	'¼': 0.25,
+	'½': 0.5,
+	'¾': 0.75,
+	'⅓': 1.0 / 3,
+	'⅔': 2.0 / 3,
+}
+
+// IssueSortKey splits the issue number into a numeric part and the suffix
+// following it, for sorting issues in publication order: "1A" gives 1 and
+// "A", "½" gives 0.5 and "", "1/2" gives 0.5 and "", "1 1/2" gives 1.5 and
+// "", "-1" gives -1 and "".
+// Issue numbers without a numeric prefix, such as "Annual", sort after all
+// numbered issues: they give +Inf and the whole issue number.
+func (i ItemInsights) IssueSortKey() (float64, string) {
+	return issueSortKey(i.IssueNumber)
+}
+
+func issueSortKey(issue string) (float64, string) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(issue), "#")
+
+	// The sign is applied last, once any fraction has been added
+	s := trimmed
+	negative := strings.HasPrefix(s, "-")
+	if negative {
+		s = s[1:]
+	}
+
+	value, suffix, hasNumber := spelledFraction(s)
+	if !hasNumber {
+		end := 0
+		for end < len(s) && (s[end] >= '0' && s[end] <= '9' || s[end] == '.') {
+			end++
+		}
+		var err error
+		value, err = strconv.ParseFloat(s[:end], 64)
+		hasNumber = err == nil
+		if !hasNumber {
+			value = 0
+		}
+		suffix = s[end:]
+
+		// A spelled out fraction following the whole number ("1 1/2")
+		if hasNumber && strings.HasPrefix(suffix, " ") {
+			if fraction, rest, ok := spelledFraction(strings.TrimLeft(suffix, " ")); ok {
+				value += fraction
+				suffix = rest
+			}
+		}
+	}
+
+	// A fraction character, alone ("½") or after the whole number ("1½")
+	if r, size := utf8.DecodeRuneInString(suffix); vulgarFractions[r] != 0 {
+		value += vulgarFractions[r]
+		suffix = suffix[size:]
+		hasNumber = true
+	}
+
+	if !hasNumber {
+		return math.Inf(1), trimmed
+	}
+	if negative {
+		value = -value
+	}
+	return value, strings.TrimSpace(suffix)
+}
+
+// spelledFraction parses a fraction spelled out with a slash, such as "1/2",
+// at the start of s
+func spelledFraction(s string) (value float64, rest string, ok bool) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 || i == len(s) || s[i] != '/' {
+		return 0, s, false
+	}
+	j := i + 1
+	for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+		j++
+	}
+	numerator, _ := strconv.Atoi(s[:i])
+	denominator, err := strconv.Atoi(s[i+1 : j])
+	if err != nil || denominator == 0 {
+		return 0, s, false
+	}
+	return float64(numerator) / float64(denominator), s[j:], true
+}
@@ -0,0 +1,34 @@
+package gocollect
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIssueSortKey(t *testing.T) {
+	tests := []struct {
+		issue      string
+		wantValue  float64
+		wantSuffix string
+	}{
+		{"1", 1, ""},
+		{"1A", 1, "A"},
+		{"#12 ", 12, ""},
+		{"½", 0.5, ""},
+		{"1½", 1.5, ""},
+		{"1/2", 0.5, ""},
+		{"1 1/2", 1.5, ""},
+		{"-1", -1, ""},
+		{"-½", -0.5, ""},
+		{"-1/2", -0.5, ""},
+		{"-1½", -1.5, ""},
+		{" #A ", math.Inf(1), "A"},
+		{"Annual", math.Inf(1), "Annual"},
+	}
+	for _, tt := range tests {
+		value, suffix := issueSortKey(tt.issue)
+		if value != tt.wantValue || suffix != tt.wantSuffix {
+			t.Errorf("issueSortKey(%q) = %v, %q, want %v, %q", tt.issue, value, suffix, tt.wantValue, tt.wantSuffix)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package gocollect
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithLenientDecode keeps the rest of a response when some of its fields
+// fail to decode, e.g. a malformed date. Such fields are left at their zero
+// value and reported to the logger, if any, one line per field, and in the
+// FieldErrors of the call's ResponseMetadata (see WithResponseMetadata).
+// Without it a single bad field fails the whole call.
+func WithLenientDecode(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.lenientDecode = enabled
+		return nil
+	}
+}
+
+// FieldDecodeError describes a response field that failed to decode in
+// lenient mode. Path locates the field, e.g. "data[3].sold_at".
+type FieldDecodeError struct {
+	Path string
+	Err  error
+}
+
+func (e FieldDecodeError) Error() string {
+	return fmt.Sprintf("gocollect: decoding %s: %v", e.Path, e.Err)
+}
+
+// decodeLenient decodes data into v field by field, logging the fields that
+// fail and recording them in the call's response metadata instead of
+// returning an error. Malformed JSON is still an error.
+func (c *Client) decodeLenient(ctx context.Context, data []byte, v interface{}) error {
+	if !json.Valid(data) {
+		return json.Unmarshal(data, v)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return json.Unmarshal(data, v)
+	}
+
+	var errs []FieldDecodeError
+	decodeLenientValue(data, rv.Elem(), "", &errs)
+	for _, err := range errs {
+		c.logf("%v", err)
+	}
+	if m := requestConfigFrom(ctx).metadata; m != nil {
+		m.FieldErrors = errs
+	}
+	return nil
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// decodeLenientValue decodes data into v, descending into structs, slices,
+// maps and pointers when decoding the whole value fails so that only the
+// failing leaves are dropped
+func decodeLenientValue(data []byte, v reflect.Value, path string, errs *[]FieldDecodeError) {
+	err := json.Unmarshal(data, v.Addr().Interface())
+	if err == nil {
+		return
+	}
+	v.Set(reflect.Zero(v.Type()))
+	if v.Addr().Type().Implements(unmarshalerType) || v.Addr().Type().Implements(textUnmarshalerType) {
+		*errs = append(*errs, FieldDecodeError{Path: path, Err: err})
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		decodeLenientValue(data, elem.Elem(), path, errs)
+		v.Set(elem)
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(data, &fields) != nil {
+			*errs = append(*errs, FieldDecodeError{Path: path, Err: err})
+			return
+		}
+		decodeLenientFields(fields, v, path, errs)
+	case reflect.Slice:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			*errs = append(*errs, FieldDecodeError{Path: path, Err: err})
+			return
+		}
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			decodeLenientValue(item, slice.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+		v.Set(slice)
+	case reflect.Map:
+		var items map[string]json.RawMessage
+		if v.Type().Key().Kind() != reflect.String || json.Unmarshal(data, &items) != nil {
+			*errs = append(*errs, FieldDecodeError{Path: path, Err: err})
+			return
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(items))
+		for key, item := range items {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			decodeLenientValue(item, elem, joinFieldPath(path, key), errs)
+			m.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+		v.Set(m)
+	default:
+		*errs = append(*errs, FieldDecodeError{Path: path, Err: err})
+	}
+}
+
+// decodeLenientFields decodes the members of a JSON object into the fields
+// of struct v, following embedded structs like encoding/json does
+func decodeLenientFields(fields map[string]json.RawMessage, v reflect.Value, path string, errs *[]FieldDecodeError) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			decodeLenientFields(fields, v.Field(i), path, errs)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		raw, ok := fields[name]
+		if !ok {
+			for key, value := range fields {
+				if strings.EqualFold(key, name) {
+					raw, ok = value, true
+					break
+				}
+			}
+		}
+		if ok {
+			decodeLenientValue(raw, v.Field(i), joinFieldPath(path, name), errs)
+		}
+	}
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,26 @@
+package gocollect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestLenientDecodeReportsFieldErrors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"used":"many","limit":100}}`))
+	}, WithLenientDecode(true))
+
+	var meta ResponseMetadata
+	ctx := WithRequestOptions(context.Background(), WithResponseMetadata(&meta))
+	usage, err := c.Account.GetUsage(ctx)
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+	if usage.Limit != 100 {
+		t.Errorf("Limit = %d, want 100", usage.Limit)
+	}
+	if len(meta.FieldErrors) != 1 || meta.FieldErrors[0].Path != "data.used" {
+		t.Errorf("FieldErrors = %v, want one error for data.used", meta.FieldErrors)
+	}
+}
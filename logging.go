@@ -0,0 +1,117 @@
+package gocollect
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Logger is the interface used by the client for diagnostic output. It is
+// satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithLogger sets the logger used for diagnostic output such as retries and
+// dry-run requests. Nothing is logged by default.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) error {
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithDryRun makes write requests (POST, PUT, PATCH and DELETE) succeed
+// without being sent. Local validation still runs, and each skipped request is
+// logged through the logger set by WithLogger. Read requests are sent as usual.
+// Write methods behave as if the server accepted the request with an empty
+// 204 response.
+func WithDryRun(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.dryRun = enabled
+		return nil
+	}
+}
+
+// WithRedactedHeaders adds headers whose values are replaced by "REDACTED"
+// wherever the client logs request or response headers. Authorization is
+// always redacted.
+func WithRedactedHeaders(names ...string) ClientOption {
+	return func(c *Client) error {
+		for _, name := range names {
+			c.redactedHeaders[http.CanonicalHeaderKey(name)] = true
+		}
+		return nil
+	}
+}
+
+// redactHeaders returns a copy of h with sensitive values replaced
+func (c *Client) redactHeaders(h http.Header) http.Header {
+	redacted := h.Clone()
+	for name := range redacted {
+		if c.redactedHeaders[http.CanonicalHeaderKey(name)] {
+			redacted[name] = []string{"REDACTED"}
+		}
+	}
+	return redacted
+}
+
+// formatHeaders renders headers on one line in a stable order
+func formatHeaders(h http.Header) string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + ": " + strings.Join(h[name], ", ")
+	}
+	return "[" + strings.Join(parts, "; ") + "]"
+}
+
+// logf writes to the logger if one is configured
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, v...)
+	}
+}
+
+// isWriteMethod reports whether method modifies server state
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// dryRunResponse logs req and returns a synthetic empty success response
+func (c *Client) dryRunResponse(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.GetBody != nil {
+		r, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		body, err = io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.logf("dry run: %s %s %s %s", req.Method, req.URL, formatHeaders(c.redactHeaders(req.Header)), strings.TrimSpace(string(body)))
+
+	return &http.Response{
+		Status:     "204 No Content",
+		StatusCode: http.StatusNoContent,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}, nil
+}
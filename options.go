@@ -0,0 +1,107 @@
+package gocollect
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestOption configures a single API call. Attach options to the call's
+// context with WithRequestOptions:
+//
+//	ctx = gocollect.WithRequestOptions(ctx, gocollect.WithPriority(gocollect.PriorityHigh))
+//	insights, err := client.Insights.GetItemInsightsWithOptions(ctx, r)
+type RequestOption func(*requestConfig)
+
+// requestConfig holds the per-call settings set by RequestOptions
+type requestConfig struct {
+	priority    Priority
+	metadata    *ResponseMetadata
+	bypassCache bool
+	timeout     time.Duration
+	// idempotent marks a POST or PATCH as safe to retry, see markIdempotent
+	idempotent bool
+}
+
+type requestConfigKey struct{}
+
+// WithRequestOptions returns a copy of ctx carrying opts, applied to every
+// call made with it. Options already carried by ctx are kept unless opts
+// overrides them.
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	cfg := requestConfigFrom(ctx)
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return context.WithValue(ctx, requestConfigKey{}, cfg)
+}
+
+// markIdempotent marks the calls made with ctx as safe to retry although
+// their method is not idempotent, such as read-only POSTs
+func markIdempotent(ctx context.Context) context.Context {
+	return WithRequestOptions(ctx, func(cfg *requestConfig) {
+		cfg.idempotent = true
+	})
+}
+
+// detachContext returns a context carrying the values of ctx, including its
+// request options, but not its cancellation, for a call shared by several
+// callers. Unless WithRequestTimeout bounds the call, it is bounded by limit.
+func detachContext(ctx context.Context, limit time.Duration) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if requestConfigFrom(ctx).timeout > 0 {
+		return context.WithCancel(detached)
+	}
+	return context.WithTimeout(detached, limit)
+}
+
+// WithRequestTimeout bounds a call, including its retries, to d. It can
+// only shorten the call: an earlier deadline already set on the context
+// still applies. It counts as a deadline for WithRequireDeadline.
+func WithRequestTimeout(d time.Duration) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.timeout = d
+	}
+}
+
+// ResponseMetadata describes the response to a call, filled in by
+// WithResponseMetadata
+type ResponseMetadata struct {
+	StatusCode int
+	// RequestID is the server's identifier for the request, to quote to
+	// GoCollect support; empty if the response did not carry one
+	RequestID string
+	Header    http.Header
+	// FieldErrors lists the response fields that failed to decode and were
+	// left at their zero value, with WithLenientDecode enabled
+	FieldErrors []FieldDecodeError
+}
+
+// WithResponseMetadata fills m with the metadata of the call's response,
+// successful or not. With retries, m describes the last attempt. m is left
+// untouched if no response was received.
+func WithResponseMetadata(m *ResponseMetadata) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.metadata = m
+	}
+}
+
+// requestIDHeaders are the response headers carrying the server's request
+// identifier, in order of preference
+var requestIDHeaders = []string{"X-Request-Id", "Request-Id"}
+
+// requestID returns the server's identifier for a request from its response headers
+func requestID(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := h.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// requestConfigFrom returns the per-call settings carried by ctx
+func requestConfigFrom(ctx context.Context) requestConfig {
+	cfg, _ := ctx.Value(requestConfigKey{}).(requestConfig)
+	return cfg
+}
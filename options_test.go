@@ -0,0 +1,61 @@
+package gocollect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithRequestTimeoutAndParentDeadline(t *testing.T) {
+	const serverDelay = 200 * time.Millisecond
+	tests := []struct {
+		name    string
+		parent  time.Duration // zero means no parent deadline
+		timeout time.Duration
+		wantErr bool
+		maxTime time.Duration
+	}{
+		{name: "parent deadline shorter than timeout", parent: 30 * time.Millisecond, timeout: time.Second, wantErr: true, maxTime: 150 * time.Millisecond},
+		{name: "parent deadline longer than timeout", parent: time.Second, timeout: 30 * time.Millisecond, wantErr: true, maxTime: 150 * time.Millisecond},
+		{name: "parent deadline longer than both", parent: 2 * time.Second, timeout: time.Second, wantErr: false, maxTime: time.Second},
+		{name: "no parent deadline, timeout expires", timeout: 30 * time.Millisecond, wantErr: true, maxTime: 150 * time.Millisecond},
+		{name: "no parent deadline, call completes", timeout: time.Second, wantErr: false, maxTime: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(serverDelay):
+					w.Write([]byte(`{"data":{"used":1,"limit":100}}`))
+				case <-r.Context().Done():
+				}
+			})
+
+			ctx := context.Background()
+			if tt.parent > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.parent)
+				defer cancel()
+			}
+			ctx = WithRequestOptions(ctx, WithRequestTimeout(tt.timeout))
+
+			start := time.Now()
+			_, err := c.Account.GetUsage(ctx)
+			elapsed := time.Since(start)
+
+			if tt.wantErr {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("error = %v, want context.DeadlineExceeded", err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if elapsed > tt.maxTime {
+				t.Errorf("call took %s, want at most %s", elapsed, tt.maxTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+//go:build otel
+
+package gocollect
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// meterName is the instrumentation scope of the SDK's metrics
+const meterName = "github.com/ZacxDev/go-gocollect-sdk"
+
+// WithMeterProvider records OpenTelemetry metrics for every request attempt:
+// a "gocollect.client.request.duration" histogram in seconds and a
+// "gocollect.client.requests" counter, both by HTTP method, route template
+// and status class ("2xx", "4xx", "5xx" or "error" for attempts that got no
+// response). A nil provider disables metrics. Only available when built
+// with -tags otel.
+func WithMeterProvider(mp metric.MeterProvider) ClientOption {
+	return func(c *Client) error {
+		if mp == nil {
+			c.observeRequest = nil
+			return nil
+		}
+
+		meter := mp.Meter(meterName)
+		duration, err := meter.Float64Histogram("gocollect.client.request.duration",
+			metric.WithDescription("Duration of GoCollect API request attempts"),
+			metric.WithUnit("s"))
+		if err != nil {
+			return err
+		}
+		requests, err := meter.Int64Counter("gocollect.client.requests",
+			metric.WithDescription("Number of GoCollect API request attempts"),
+			metric.WithUnit("{request}"))
+		if err != nil {
+			return err
+		}
+
+		c.observeRequest = func(ctx context.Context, o requestObservation) {
+			attrs := metric.WithAttributes(
+				attribute.String("http.request.method", o.Method),
+				attribute.String("url.template", o.Route),
+				attribute.String("http.response.status_class", statusClass(o.StatusCode)),
+			)
+			duration.Record(ctx, o.Duration.Seconds(), attrs)
+			requests.Add(ctx, 1, attrs)
+		}
+		return nil
+	}
+}
+
+// statusClass groups a status code by its first digit, e.g. "4xx"
+func statusClass(code int) string {
+	if code == 0 {
+		return "error"
+	}
+	return fmt.Sprintf("%dxx", code/100)
+}
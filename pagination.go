@@ -0,0 +1,74 @@
+package gocollect
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// listPath returns the path of a list request: opts.Next when following a
+// Link header, otherwise base with the encoded params
+func listPath(base string, params url.Values, opts ListOptions) string {
+	if opts.Next != "" {
+		return opts.Next
+	}
+	return base + "?" + params.Encode()
+}
+
+// nextPage advances opts past the page described by meta, preferring the
+// server's Link header over the page number
+func (o *ListOptions) nextPage(meta *ListMeta) {
+	if meta.NextURL != "" {
+		o.Next = meta.NextURL
+		return
+	}
+	o.Next = ""
+	o.Page = meta.CurrentPage + 1
+}
+
+// withLinkHeader records the rel="next" Link of resp in meta. Links to
+// another origin are ignored so the token is never sent elsewhere.
+func (c *Client) withLinkHeader(resp *http.Response, meta *ListMeta) *ListMeta {
+	if resp == nil || resp.Request == nil {
+		return meta
+	}
+	next := parseNextLink(resp.Header.Values("Link"))
+	if next == "" {
+		return meta
+	}
+	u, err := resp.Request.URL.Parse(next)
+	if err != nil || u.Scheme != c.baseURL.Scheme || u.Host != c.baseURL.Host {
+		return meta
+	}
+	if meta == nil {
+		meta = new(ListMeta)
+	}
+	meta.NextURL = u.String()
+	return meta
+}
+
+// parseNextLink returns the target of the rel="next" link in RFC 8288 Link
+// header values, e.g. `<https://example.com/items?page=2>; rel="next"`
+func parseNextLink(values []string) string {
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			target = strings.TrimSpace(target)
+			if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
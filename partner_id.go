@@ -0,0 +1,39 @@
+package gocollect
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// GeneratePartnerSaleID derives a partner sale id from a sold example, for
+// sales without a natural one. The id is the hex SHA-256 of the URL, the
+// certification key and the sale time in UTC as RFC 3339 with nanoseconds,
+// each trimmed of surrounding spaces and separated by newlines, truncated to
+// 32 characters. A missing certification key counts as empty. These inputs
+// are fixed, so the same sale yields the same id across runs and SDK
+// versions.
+func GeneratePartnerSaleID(e *SoldExample) string {
+	var key string
+	if e.CertificationKey != nil {
+		key = strings.TrimSpace(*e.CertificationKey)
+	}
+	input := strings.Join([]string{
+		strings.TrimSpace(e.URL),
+		key,
+		e.SoldAt.UTC().Format(time.RFC3339Nano),
+	}, "\n")
+	sum := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(sum[:])[:32]
+}
+
+// WithGeneratedPartnerSaleIDs makes CreateSoldExample set the PartnerSaleID
+// of examples that have none with GeneratePartnerSaleID, so that submitting
+// the same sale again is recognized as a duplicate
+func WithGeneratedPartnerSaleIDs(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.autoPartnerIDs = enabled
+		return nil
+	}
+}
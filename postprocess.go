@@ -0,0 +1,47 @@
+package gocollect
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WithResultPostProcessor registers fn to be called with every successfully
+// decoded response, e.g. to trim titles or lowercase CAMs in one place.
+// fn receives a pointer to the result: for endpoints that wrap their result
+// in a "data" envelope this is a pointer to the unwrapped value, such as a
+// *[]SoldExample or a *StagedSale. Processors run in registration order.
+func WithResultPostProcessor(fn func(v interface{})) ClientOption {
+	return func(c *Client) error {
+		c.postProcessors = append(c.postProcessors, fn)
+		return nil
+	}
+}
+
+// postProcess runs the registered post-processors on a decoded result
+func (c *Client) postProcess(v interface{}) {
+	if len(c.postProcessors) == 0 {
+		return
+	}
+	v = unwrapDataEnvelope(v)
+	for _, fn := range c.postProcessors {
+		fn(v)
+	}
+}
+
+// unwrapDataEnvelope returns a pointer to the "data" field of a response
+// envelope, or v itself when it is not one
+func unwrapDataEnvelope(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return v
+	}
+	elem := rv.Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "data" && field.IsExported() {
+			return elem.Field(i).Addr().Interface()
+		}
+	}
+	return v
+}
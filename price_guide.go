@@ -0,0 +1,74 @@
+package gocollect
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// PriceGuide is an item's price guide: its metrics for every grade and
+// period the API reports, for one certification company
+type PriceGuide struct {
+	ItemID  int    `json:"item_id"`
+	Company string `json:"company"`
+	// Cells maps grade, then period, to the metrics of that cell. A nil
+	// entry is a cell the API reported without data; use Cell rather than
+	// indexing to treat those and absent cells alike.
+	Cells map[string]map[MetricsPeriod]*Metrics `json:"grades"`
+}
+
+// Cell returns the metrics for a grade and period, with ok false if the
+// cell has no data
+func (g *PriceGuide) Cell(grade string, period MetricsPeriod) (m Metrics, ok bool) {
+	cell := g.Cells[grade][period]
+	if cell == nil {
+		return Metrics{}, false
+	}
+	return *cell, true
+}
+
+// Grades returns the grades of the guide, highest first. Grades that are
+// not numbers sort last in name order.
+func (g *PriceGuide) Grades() []string {
+	grades := make([]string, 0, len(g.Cells))
+	for grade := range g.Cells {
+		grades = append(grades, grade)
+	}
+	sort.Slice(grades, func(a, b int) bool {
+		va, errA := strconv.ParseFloat(grades[a], 64)
+		vb, errB := strconv.ParseFloat(grades[b], 64)
+		switch {
+		case errA == nil && errB == nil && va != vb:
+			return va > vb
+		case (errA == nil) != (errB == nil):
+			return errA == nil
+		}
+		return grades[a] < grades[b]
+	})
+	return grades
+}
+
+// GetPriceGuide retrieves the price guide of an item, its metrics across
+// grades and periods, for a certification company. An empty company uses the
+// API's default.
+func (s *InsightsService) GetPriceGuide(ctx context.Context, itemID int, company string) (*PriceGuide, error) {
+	params := url.Values{}
+	if company != "" {
+		params.Add("company", company)
+	}
+
+	path := fmt.Sprintf("%s/item/%d/price-guide?%s", s.client.apiBase(APIInsights), itemID, params.Encode())
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	guide := new(PriceGuide)
+	_, err = s.client.do(req, guide)
+	if err != nil {
+		return nil, err
+	}
+	return guide, nil
+}
@@ -0,0 +1,221 @@
+package gocollect
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Priority orders calls waiting on the client-side rate limiter. When no
+// token is available, waiting calls of a higher priority are served first and
+// calls of equal priority in arrival order.
+type Priority int
+
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+// WithPriority sets the priority of a call for the client-side rate limiters;
+// it has no effect unless WithRateLimit or a per-API rate limit is used
+func WithPriority(p Priority) RequestOption {
+	return func(cfg *requestConfig) {
+		cfg.priority = p
+	}
+}
+
+// WithRateLimit limits the client to perSecond requests per second on
+// average, allowing bursts of up to burst requests. Calls over the limit
+// wait for a token, served by priority (see WithPriority). Each retry
+// attempt takes a token.
+func WithRateLimit(perSecond float64, burst int) ClientOption {
+	return func(c *Client) error {
+		if perSecond <= 0 {
+			return fmt.Errorf("gocollect: rate limit must be positive, got %v", perSecond)
+		}
+		if burst < 1 {
+			return fmt.Errorf("gocollect: rate limit burst must be at least 1, got %d", burst)
+		}
+		c.limiter = newRateLimiter(perSecond, burst)
+		return nil
+	}
+}
+
+// WithInsightsRateLimit limits insights requests like WithRateLimit, with a
+// limiter of their own, so that they are not held up by bursts of other
+// requests. When WithRateLimit is also used, insights requests take a token
+// from both limiters.
+func WithInsightsRateLimit(perSecond float64, burst int) ClientOption {
+	return withAPIRateLimit(APIInsights, perSecond, burst)
+}
+
+// WithResourcesRateLimit limits sold example and staged sale requests like
+// WithRateLimit, with a limiter of their own, so that write bursts do not
+// hold up other requests. When WithRateLimit is also used, these requests
+// take a token from both limiters.
+func WithResourcesRateLimit(perSecond float64, burst int) ClientOption {
+	return withAPIRateLimit(APIResources, perSecond, burst)
+}
+
+func withAPIRateLimit(api API, perSecond float64, burst int) ClientOption {
+	return func(c *Client) error {
+		if perSecond <= 0 {
+			return fmt.Errorf("gocollect: %s rate limit must be positive, got %v", api, perSecond)
+		}
+		if burst < 1 {
+			return fmt.Errorf("gocollect: %s rate limit burst must be at least 1, got %d", api, burst)
+		}
+		if c.apiLimiters == nil {
+			c.apiLimiters = make(map[API]*rateLimiter)
+		}
+		c.apiLimiters[api] = newRateLimiter(perSecond, burst)
+		return nil
+	}
+}
+
+// waitForToken blocks until the limiters that apply to a request to path,
+// if any, grant a token
+func (c *Client) waitForToken(ctx context.Context, path string, p Priority) error {
+	for api, limiter := range c.apiLimiters {
+		if strings.Contains(path, "/api/"+string(api)+"/") {
+			if err := limiter.wait(ctx, p); err != nil {
+				return err
+			}
+		}
+	}
+	return c.limiter.wait(ctx, p)
+}
+
+// rateLimiter is a token bucket handing out tokens to waiters by priority
+type rateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	tokens  float64
+	last    time.Time
+	waiters waiterQueue
+	seq     uint64
+	timer   *time.Timer
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is granted or ctx is done. A nil limiter never blocks.
+func (l *rateLimiter) wait(ctx context.Context, priority Priority) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	l.refill()
+	if len(l.waiters) == 0 && l.tokens >= 1 {
+		l.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+	l.seq++
+	w := &waiter{priority: priority, seq: l.seq, ready: make(chan struct{})}
+	heap.Push(&l.waiters, w)
+	l.schedule()
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if w.granted {
+			// Hand the token on rather than waste it
+			l.tokens++
+		} else {
+			heap.Remove(&l.waiters, w.index)
+		}
+		l.schedule()
+		return ctx.Err()
+	}
+}
+
+// refill adds the tokens accrued since the last refill. l.mu must be held.
+func (l *rateLimiter) refill() {
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// schedule grants the available tokens to the waiters in priority order and
+// arms a timer for the next token if any remain waiting. l.mu must be held.
+func (l *rateLimiter) schedule() {
+	l.refill()
+	for len(l.waiters) > 0 && l.tokens >= 1 {
+		w := heap.Pop(&l.waiters).(*waiter)
+		l.tokens--
+		w.granted = true
+		close(w.ready)
+	}
+	if len(l.waiters) == 0 || l.timer != nil {
+		return
+	}
+	delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	l.timer = time.AfterFunc(delay, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.timer = nil
+		l.schedule()
+	})
+}
+
+// waiter is a call blocked on the rate limiter
+type waiter struct {
+	priority Priority
+	seq      uint64
+	index    int
+	granted  bool
+	ready    chan struct{}
+}
+
+// waiterQueue is a heap of waiters, highest priority and then earliest first
+type waiterQueue []*waiter
+
+func (q waiterQueue) Len() int { return len(q) }
+
+func (q waiterQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q waiterQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waiterQueue) Push(x interface{}) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waiterQueue) Pop() interface{} {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return w
+}
@@ -0,0 +1,62 @@
+package gocollect
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// WithCaptureRaw sets the Raw field of decoded records, such as SoldExample
+// or ItemInsights, to the JSON they were decoded from, giving access to
+// fields the SDK does not model yet. It is off by default since it keeps a
+// copy of every response body.
+func WithCaptureRaw(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.captureRaw = enabled
+		return nil
+	}
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+// captureRawJSON sets the Raw fields of the records in the decoded value v
+// from data, looking through a "data" envelope
+func captureRawJSON(data []byte, v interface{}) {
+	if target := unwrapDataEnvelope(v); target != v {
+		var envelope struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if json.Unmarshal(data, &envelope) != nil {
+			return
+		}
+		data, v = envelope.Data, target
+	}
+	assignRaw(reflect.ValueOf(v), data)
+}
+
+// assignRaw sets the Raw field of v, or of the elements of v if it is a
+// slice, to the matching part of data
+func assignRaw(v reflect.Value, data []byte) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			assignRaw(v.Elem(), data)
+		}
+	case reflect.Struct:
+		raw := v.FieldByName("Raw")
+		if raw.IsValid() && raw.Type() == rawMessageType && raw.CanSet() {
+			raw.SetBytes(bytes.Clone(data))
+		}
+	case reflect.Slice:
+		if v.Type() == rawMessageType {
+			return
+		}
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return
+		}
+		for i := 0; i < v.Len() && i < len(items); i++ {
+			assignRaw(v.Index(i), items[i])
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package gocollect
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// retryBaseDelay is the delay before the first retry
+	retryBaseDelay = 500 * time.Millisecond
+	// retryMaxDelay caps the delay between two attempts
+	retryMaxDelay = 30 * time.Second
+	// maintenanceMaxDelay caps the wait for a maintenance window to end
+	maintenanceMaxDelay = 5 * time.Minute
+	// defaultRetryAfterCeiling is the longest Retry-After wait honored
+	// unless WithRetryAfterCeiling is used
+	defaultRetryAfterCeiling = 60 * time.Second
+)
+
+// WithMaxRetries retries requests that fail with a network error, a 429 or a
+// 5xx status up to n times, waiting an exponentially growing, jittered delay
+// between attempts. Requests are not retried by default.
+//
+// Only requests that are safe to repeat are retried: GET, HEAD, PUT and
+// DELETE requests, and read-only POSTs such as bulk lookups. Other writes,
+// such as creates, PatchStagedSale or RetractSoldExample, are attempted once
+// since a failed attempt may still have been applied.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 0 {
+			return fmt.Errorf("gocollect: max retries must not be negative, got %d", n)
+		}
+		c.maxRetries = n
+		return nil
+	}
+}
+
+// WithMaxElapsedTime bounds the total time spent on one call including
+// retries. No retry is attempted once it would start after d has elapsed, and
+// the last error is returned instead. The context deadline and the retry count
+// still apply, whichever is reached first.
+func WithMaxElapsedTime(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d <= 0 {
+			return fmt.Errorf("gocollect: max elapsed time must be positive, got %s", d)
+		}
+		c.maxElapsedTime = d
+		return nil
+	}
+}
+
+// WithRetryAfterCeiling sets the longest wait honored when a 429 response
+// asks to be retried later with a Retry-After header, 60 seconds by default.
+// If the server asks for a longer wait, the call fails right away with an
+// error matching ErrRateLimited instead of blocking. It only applies when
+// retries are enabled with WithMaxRetries.
+func WithRetryAfterCeiling(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d <= 0 {
+			return fmt.Errorf("gocollect: retry after ceiling must be positive, got %s", d)
+		}
+		c.retryAfterCeiling = d
+		return nil
+	}
+}
+
+// WithRetryPredicate replaces the rules deciding whether a failed attempt is
+// retried. fn is called with the error and, unless the attempt failed without
+// a response, the response; an error response's body has been buffered and
+// may be read freely. Call DefaultRetryPredicate from fn to extend the
+// default rules rather than replace them. Writes that are not safe to repeat
+// are never retried, whatever fn returns (see WithMaxRetries).
+func WithRetryPredicate(fn func(resp *http.Response, err error) bool) ClientOption {
+	return func(c *Client) error {
+		if fn == nil {
+			return errors.New("gocollect: retry predicate must not be nil")
+		}
+		c.retryPredicate = fn
+		return nil
+	}
+}
+
+// shouldRetry reports whether a failed attempt of req may be retried
+func (c *Client) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	ctx := req.Context()
+	if err == nil || ctx.Err() != nil || !isIdempotent(req) {
+		return false
+	}
+	if c.retryPredicate == nil {
+		return DefaultRetryPredicate(resp, err)
+	}
+
+	var apiErr *APIError
+	if resp != nil && errors.As(err, &apiErr) {
+		resp.Body = io.NopCloser(bytes.NewReader(apiErr.Body))
+	}
+	return c.retryPredicate(resp, err)
+}
+
+// isIdempotent reports whether req may be sent again without repeating its
+// effect
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return requestConfigFrom(req.Context()).idempotent
+}
+
+// DefaultRetryPredicate reports whether an attempt failed in a way that may
+// succeed when tried again: a network error, a 429 or a 5xx response
+func DefaultRetryPredicate(resp *http.Response, err error) bool {
+	return isTransient(resp, err)
+}
+
+// isTransient reports whether an attempt failed in a way that may succeed
+// when tried again: a network error, a 429 or a 5xx response
+func isTransient(resp *http.Response, err error) bool {
+	if err == nil || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		code := apiErr.StatusCode
+		return code == http.StatusTooManyRequests ||
+			(code >= 500 && code != http.StatusNotImplemented)
+	}
+
+	// Anything else without a response is a transport error
+	return resp == nil
+}
+
+// retryDelay returns the delay before retrying after err. During API
+// maintenance it waits until the announced end of the window, up to
+// maintenanceMaxDelay, and after a 429 for as long as its Retry-After header
+// asks; otherwise it backs off exponentially. It returns an error when the
+// requested wait exceeds the Retry-After ceiling.
+func (c *Client) retryDelay(attempt int, err error) (time.Duration, error) {
+	var maintenanceErr *MaintenanceError
+	if errors.As(err, &maintenanceErr) {
+		delay := time.Until(maintenanceErr.RetryAt)
+		if delay > maintenanceMaxDelay {
+			delay = maintenanceMaxDelay
+		}
+		if delay > 0 {
+			return delay, nil
+		}
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests && apiErr.Response != nil {
+		if retryAt, ok := parseRetryAfter(apiErr.Response.Header.Get("Retry-After"), time.Now()); ok {
+			delay := time.Until(retryAt)
+			ceiling := c.retryAfterCeiling
+			if ceiling <= 0 {
+				ceiling = defaultRetryAfterCeiling
+			}
+			if delay > ceiling {
+				return 0, fmt.Errorf("%w: server asked to wait %s, more than %s: %w",
+					ErrRateLimited, delay.Round(time.Second), ceiling, err)
+			}
+			if delay > 0 {
+				return delay, nil
+			}
+		}
+	}
+	return c.backoff(attempt), nil
+}
+
+// backoff returns the delay before retry number attempt, counting from zero
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := retryMaxDelay
+	if attempt < 16 {
+		if d := retryBaseDelay << uint(attempt); d < retryMaxDelay {
+			delay = d
+		}
+	}
+	return c.jitter(delay)
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rewindRequest returns a copy of req with a fresh body for another attempt
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = body
+	}
+	return next, nil
+}
@@ -0,0 +1,78 @@
+package gocollect
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRetryOnlyIdempotentRequests(t *testing.T) {
+	tests := []struct {
+		name         string
+		call         func(ctx context.Context, c *Client) error
+		wantAttempts int32
+	}{
+		{
+			name: "get",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Account.GetUsage(ctx)
+				return err
+			},
+			wantAttempts: 3,
+		},
+		{
+			name: "read-only post",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Collectibles.postSearchItemsBulk(ctx, []string{"hulk"}, SearchItemsOptions{})
+				return err
+			},
+			wantAttempts: 3,
+		},
+		{
+			name: "create",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.StagedSales.createStagedSale(ctx, &StagedSale{
+					PartnerSaleID: "1",
+					CAM:           "Comics",
+					Title:         "X-Men #1",
+					URL:           "https://example.com/sale/1",
+					Format:        SaleFormatAuction,
+				})
+				return err
+			},
+			wantAttempts: 1,
+		},
+		{
+			name: "patch",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.StagedSales.PatchStagedSale(ctx, "1", map[string]interface{}{"price": 1})
+				return err
+			},
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(http.StatusBadGateway)
+			}, WithMaxRetries(2), WithRandSource(zeroSource{}))
+			if err := tt.call(context.Background(), c); err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+// zeroSource is a rand.Source always returning zero, making retry jitter
+// and so backoff delays minimal
+type zeroSource struct{}
+
+func (zeroSource) Int63() int64 { return 0 }
+func (zeroSource) Seed(int64)   {}
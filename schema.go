@@ -0,0 +1,58 @@
+package gocollect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// WithSchemaGuard reports responses whose fields no longer have the type
+// the SDK expects, e.g. a number that became an object, as a *SchemaError
+// naming the field, rather than a bare decoding error. It adds no cost to
+// responses that decode. WithLenientDecode takes precedence: with both
+// enabled, drifted fields are logged and skipped.
+func WithSchemaGuard(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.schemaGuard = enabled
+		return nil
+	}
+}
+
+// SchemaError is returned when WithSchemaGuard is enabled and a response
+// field has an unexpected JSON type, indicating the API changed
+type SchemaError struct {
+	// Field is the path of the field in the response, e.g. "data.sold_price"
+	Field string
+	// Expected is the Go type the SDK decodes the field into
+	Expected string
+	// Actual is the JSON type received, e.g. "object" or "string"
+	Actual string
+	Err    error
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("gocollect: response field %q changed type: expected %s, got %s", e.Field, e.Expected, e.Actual)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// schemaError converts a decoding error caused by a type mismatch into a
+// *SchemaError, returning other errors unchanged
+func schemaError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+	field := typeErr.Field
+	if field == "" {
+		field = "(root)"
+	}
+	return &SchemaError{
+		Field:    field,
+		Expected: typeErr.Type.String(),
+		Actual:   typeErr.Value,
+		Err:      err,
+	}
+}
@@ -2,16 +2,44 @@ package gocollect
 
 import (
 	"bytes"
+	"context"
+	crand "crypto/rand"
+	"crypto/tls"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultBaseURL    = "https://gocollect.com"
+	defaultAPIVersion = "v1"
 )
 
+// API identifies one of the GoCollect APIs, which are versioned independently
+type API string
+
 const (
-	defaultBaseURL = "https://gocollect.com"
+	APIAccount      API = "account"
+	APICollectibles API = "collectibles"
+	APIInsights     API = "insights"
+	APIResources    API = "resources"
 )
 
 // Client manages communication with the GoCollect API
@@ -20,11 +48,56 @@ type Client struct {
 	baseURL *url.URL
 	token   string
 
+	tokenSource TokenSource
+	withoutAuth bool
+
+	apiVersion         string
+	apiVersions        map[API]string
+	redirectPolicy     RedirectPolicy
+	companyLabels      map[string]map[string]bool
+	inFlight           chan struct{}
+	requireDeadline    bool
+	maxRequestBodySize int64
+
+	compression       bool
+	defaultLimit      int
+	baseCtx           context.Context
+	newEncoder        func(io.Writer) *json.Encoder
+	itemResolution    bool
+	autoPartnerIDs    bool
+	logger            Logger
+	redactedHeaders   map[string]bool
+	bufferPooling     bool
+	lenientDecode     bool
+	schemaGuard       bool
+	captureRaw        bool
+	strictEnums       bool
+	dryRun            bool
+	postProcessors    []func(v interface{})
+	insightsDedup     *singleflight.Group
+	limiter           *rateLimiter
+	apiLimiters       map[API]*rateLimiter
+	observeRequest    func(context.Context, requestObservation)
+	coalescer         *writeCoalescer
+	errorMapper       func(resp *http.Response, body []byte) error
+	etagCache         ETagCache
+	deprecationNotice func(path string, sunset time.Time)
+	maxRetries        int
+	retryPredicate    func(resp *http.Response, err error) bool
+	breaker           *circuitBreaker
+	maxElapsedTime    time.Duration
+	retryAfterCeiling time.Duration
+
+	randMu sync.Mutex
+	rand   *rand.Rand
+
 	// Services
+	Account      *AccountService
 	Collectibles *CollectiblesService
 	Insights     *InsightsService
 	SoldExamples *SoldExamplesService
 	StagedSales  *StagedSalesService
+	Watchlist    *WatchlistService
 }
 
 // ClientOption is a function that modifies the client
@@ -34,9 +107,17 @@ type ClientOption func(*Client) error
 func NewClient(token string, opts ...ClientOption) (*Client, error) {
 	baseURL, _ := url.Parse(defaultBaseURL)
 	c := &Client{
-		client:  http.DefaultClient,
-		baseURL: baseURL,
-		token:   token,
+		client:        http.DefaultClient,
+		baseURL:       baseURL,
+		token:         token,
+		apiVersion:    defaultAPIVersion,
+		apiVersions:   make(map[API]string),
+		companyLabels: defaultCompanyLabelSet(),
+		rand:          rand.New(newSecureSource()),
+		newEncoder:    json.NewEncoder,
+		redactedHeaders: map[string]bool{
+			"Authorization": true,
+		},
 	}
 
 	// Apply options
@@ -45,12 +126,23 @@ func NewClient(token string, opts ...ClientOption) (*Client, error) {
 			return nil, err
 		}
 	}
+	if err := c.validateAuth(); err != nil {
+		return nil, err
+	}
+
+	if c.client.CheckRedirect == nil {
+		httpClient := *c.client
+		httpClient.CheckRedirect = c.checkRedirect
+		c.client = &httpClient
+	}
 
 	// Initialize services
+	c.Account = &AccountService{client: c}
 	c.Collectibles = &CollectiblesService{client: c}
 	c.Insights = &InsightsService{client: c}
 	c.SoldExamples = &SoldExamplesService{client: c}
 	c.StagedSales = &StagedSalesService{client: c}
+	c.Watchlist = &WatchlistService{client: c}
 
 	return c, nil
 }
@@ -58,7 +150,7 @@ func NewClient(token string, opts ...ClientOption) (*Client, error) {
 // WithBaseURL sets a custom base URL for the client
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) error {
-		parsedURL, err := url.Parse(baseURL)
+		parsedURL, err := parseBaseURL(baseURL)
 		if err != nil {
 			return err
 		}
@@ -67,283 +159,1969 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
-// WithHTTPClient sets a custom HTTP client
-func WithHTTPClient(httpClient *http.Client) ClientOption {
+// WithBaseURLFromEnv sets the base URL from the named environment variable
+func WithBaseURLFromEnv(varName string) ClientOption {
 	return func(c *Client) error {
-		c.client = httpClient
+		value := os.Getenv(varName)
+		if value == "" {
+			return fmt.Errorf("gocollect: environment variable %s is not set", varName)
+		}
+		parsedURL, err := parseBaseURL(value)
+		if err != nil {
+			return fmt.Errorf("gocollect: environment variable %s: %w", varName, err)
+		}
+		c.baseURL = parsedURL
 		return nil
 	}
 }
 
-// newRequest creates a new API request
-func (c *Client) newRequest(method, path string, body interface{}) (*http.Request, error) {
-	u, err := c.baseURL.Parse(path)
-	if err != nil {
-		return nil, err
+// WithAPIVersion sets the version path segment used for all APIs, e.g. "v2"
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) error {
+		if err := validateAPIVersion(version); err != nil {
+			return err
+		}
+		c.apiVersion = version
+		return nil
 	}
+}
 
-	var buf io.ReadWriter
-	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
-		if err != nil {
-			return nil, err
+// WithServiceAPIVersion sets the version path segment for a single API,
+// overriding WithAPIVersion for it
+func WithServiceAPIVersion(api API, version string) ClientOption {
+	return func(c *Client) error {
+		if err := validateAPIVersion(version); err != nil {
+			return err
 		}
+		c.apiVersions[api] = version
+		return nil
 	}
+}
 
-	req, err := http.NewRequest(method, u.String(), buf)
-	if err != nil {
-		return nil, err
+// validateAPIVersion ensures version is a single path segment
+func validateAPIVersion(version string) error {
+	if version == "" || strings.ContainsAny(version, "/?#") {
+		return fmt.Errorf("gocollect: invalid API version %q", version)
 	}
+	return nil
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+// apiBase returns the versioned path prefix of an API, e.g. "/api/insights/v1"
+func (c *Client) apiBase(api API) string {
+	version, ok := c.apiVersions[api]
+	if !ok {
+		version = c.apiVersion
 	}
+	return "/api/" + string(api) + "/" + version
+}
 
-	return req, nil
+// WithTokenFromEnv sets the API token from the named environment variable,
+// e.g. NewClient("", WithTokenFromEnv("GOCOLLECT_TOKEN"))
+func WithTokenFromEnv(varName string) ClientOption {
+	return func(c *Client) error {
+		token := os.Getenv(varName)
+		if token == "" {
+			return fmt.Errorf("gocollect: environment variable %s is not set", varName)
+		}
+		c.token = token
+		return nil
+	}
 }
 
-// do sends an API request and returns the response
-func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := c.client.Do(req)
+// parseBaseURL parses and validates an absolute http(s) base URL
+func parseBaseURL(baseURL string) (*url.URL, error) {
+	parsedURL, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+	if parsedURL.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: missing host", baseURL)
+	}
+	return parsedURL, nil
+}
 
-	if resp.StatusCode >= 400 {
-		return resp, fmt.Errorf("API request failed with status code: %d", resp.StatusCode)
+// WithHTTPClient sets a custom HTTP client
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) error {
+		c.client = httpClient
+		return nil
 	}
+}
 
-	if v != nil && resp.StatusCode != http.StatusNoContent {
-		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
-			return resp, err
+// RedirectPolicy controls how the client follows HTTP redirects
+type RedirectPolicy int
+
+const (
+	// RedirectSameOrigin follows redirects to the same scheme and host,
+	// keeping the Authorization header, and refuses all others. This is the
+	// default.
+	RedirectSameOrigin RedirectPolicy = iota
+	// RedirectAllowCrossOrigin also follows redirects to other origins, but
+	// never forwards the Authorization header to them.
+	RedirectAllowCrossOrigin
+	// RedirectNone refuses to follow any redirect.
+	RedirectNone
+)
+
+// maxRedirects is the number of redirects followed before giving up
+const maxRedirects = 10
+
+// WithRedirectPolicy sets how redirects are followed.
+//
+// The API token is never sent to a different origin: a redirect to another
+// host could leak the token to a third party, so cross-origin redirects are
+// either refused or followed without credentials. The policy is not applied
+// when a client passed to WithHTTPClient has its own CheckRedirect.
+func WithRedirectPolicy(policy RedirectPolicy) ClientOption {
+	return func(c *Client) error {
+		c.redirectPolicy = policy
+		return nil
+	}
+}
+
+// checkRedirect implements the client's RedirectPolicy
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if c.redirectPolicy == RedirectNone {
+		return http.ErrUseLastResponse
+	}
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("gocollect: stopped after %d redirects", maxRedirects)
+	}
+
+	origin := via[0].URL
+	if req.URL.Scheme == origin.Scheme && req.URL.Host == origin.Host {
+		if auth := via[0].Header.Get("Authorization"); auth != "" {
+			req.Header.Set("Authorization", auth)
 		}
+		return nil
 	}
 
-	return resp, nil
+	if c.redirectPolicy != RedirectAllowCrossOrigin {
+		return fmt.Errorf("gocollect: refusing cross-origin redirect from %s to %s", origin.Host, req.URL.Host)
+	}
+	req.Header.Del("Authorization")
+	return nil
 }
 
-// CollectiblesService handles communication with the collectible related endpoints
-type CollectiblesService struct {
-	client *Client
+// WithMaxConcurrentRequests caps the number of requests the client has in
+// flight at once, across all goroutines. Callers beyond the cap wait for a
+// slot or until their context is done. Batch helpers such as
+// GetSoldExamplesByIDs keep their own concurrency limit, so the effective
+// concurrency is the lower of the two.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) error {
+		if n <= 0 {
+			return fmt.Errorf("gocollect: max concurrent requests must be positive, got %d", n)
+		}
+		c.inFlight = make(chan struct{}, n)
+		return nil
+	}
 }
 
-// SearchItemsOptions represents the parameters for searching items
-type SearchItemsOptions struct {
-	Query string
-	CAM   string
-	Limit int
+// WithDefaultLimit sets the page size used by search and list methods when a
+// call does not specify a limit. Per-call limits still take precedence.
+func WithDefaultLimit(n int) ClientOption {
+	return func(c *Client) error {
+		if n <= 0 {
+			return fmt.Errorf("gocollect: default limit must be positive, got %d", n)
+		}
+		c.defaultLimit = n
+		return nil
+	}
 }
 
-// SearchItem represents a collectible item in search results
-type SearchItem struct {
-	ItemID             int     `json:"item_id"`
-	UUID               string  `json:"uuid"`
-	Slug               string  `json:"slug"`
-	Name               string  `json:"name"`
-	VariantOfItemID    *int    `json:"variant_of_item_id"`
-	VariantDescription *string `json:"variant_description"`
+// maxPooledBufferSize keeps unusually large response buffers out of the pool
+const maxPooledBufferSize = 1 << 20
+
+// bufferPool holds the buffers used to read response bodies when pooling is enabled
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
 }
 
-// SearchItems searches for collectible items
-func (s *CollectiblesService) SearchItems(opts SearchItemsOptions) ([]SearchItem, error) {
-	params := url.Values{}
-	params.Add("query", opts.Query)
-	if opts.CAM != "" {
-		params.Add("cam", opts.CAM)
+// WithBufferPooling reads response bodies into buffers reused across
+// requests before decoding them, reducing allocations and GC pressure for
+// clients making many requests. Decoding results are unchanged.
+func WithBufferPooling(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.bufferPooling = enabled
+		return nil
 	}
-	if opts.Limit > 0 {
-		params.Add("limit", fmt.Sprintf("%d", opts.Limit))
+}
+
+// WithContext sets a base context for every request, typically one canceled
+// on service shutdown. A request is canceled when either its own context or
+// the base context is done; the deadline of the per-call context still applies.
+func WithContext(ctx context.Context) ClientOption {
+	return func(c *Client) error {
+		if ctx == nil {
+			return errors.New("gocollect: context must not be nil")
+		}
+		c.baseCtx = ctx
+		return nil
+	}
+}
+
+// mergeContexts returns a context derived from ctx that is also canceled when
+// base is done. The returned function releases its resources.
+func mergeContexts(ctx, base context.Context) (context.Context, func()) {
+	merged, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(base, func() {
+		cancel(context.Cause(base))
+	})
+	return merged, func() {
+		stop()
+		cancel(context.Canceled)
 	}
+}
+
+// WithItemResolution makes the create methods look up a missing
+// GocollectItemID from the certification company and key before sending,
+// using ResolveItemByCertification. This costs an extra request per record.
+// Records whose certificate is unknown are created without an item id.
+func WithItemResolution(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.itemResolution = enabled
+		return nil
+	}
+}
 
-	path := fmt.Sprintf("/api/collectibles/v1/item/search?%s", params.Encode())
-	req, err := s.client.newRequest("GET", path, nil)
+// resolveItemID returns itemID, or the id resolved from the certification if
+// itemID is nil and item resolution is enabled
+func (c *Client) resolveItemID(ctx context.Context, itemID *int, company CertificationCompany, key *string) (*int, error) {
+	if !c.itemResolution || itemID != nil || company == "" || key == nil || *key == "" {
+		return itemID, nil
+	}
+	item, err := c.Collectibles.ResolveItemByCertification(ctx, string(company), *key)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-
-	var items []SearchItem
-	_, err = s.client.do(req, &items)
-	return items, err
+	return &item.ItemID, nil
 }
 
-// InsightsService handles communication with the insights related endpoints
-type InsightsService struct {
-	client *Client
+// WithJSONEncoder sets the constructor of the encoder used for request
+// bodies, e.g. to disable HTML escaping so URLs containing "&" are sent
+// verbatim:
+//
+//	gocollect.WithJSONEncoder(func(w io.Writer) *json.Encoder {
+//		enc := json.NewEncoder(w)
+//		enc.SetEscapeHTML(false)
+//		return enc
+//	})
+func WithJSONEncoder(fn func(io.Writer) *json.Encoder) ClientOption {
+	return func(c *Client) error {
+		if fn == nil {
+			return errors.New("gocollect: JSON encoder constructor must not be nil")
+		}
+		c.newEncoder = fn
+		return nil
+	}
 }
 
-// Metrics represents sales metrics for a specific time period
-type Metrics struct {
-	SoldCount    int     `json:"sold_count"`
-	LowPrice     float64 `json:"low_price"`
-	HighPrice    float64 `json:"high_price"`
-	AveragePrice float64 `json:"average_price"`
+// WithMaxRequestBodySize makes requests whose encoded JSON body is larger
+// than n bytes fail before they are sent, with an error matching
+// ErrRequestBodyTooLarge that gives both sizes, rather than being rejected
+// by the API
+func WithMaxRequestBodySize(n int64) ClientOption {
+	return func(c *Client) error {
+		if n <= 0 {
+			return fmt.Errorf("gocollect: max request body size must be positive, got %d", n)
+		}
+		c.maxRequestBodySize = n
+		return nil
+	}
 }
 
-// ItemInsights represents insights for a collectible item
-type ItemInsights struct {
-	ItemID      int                `json:"item_id"`
-	Title       string             `json:"title"`
-	IssueNumber string             `json:"issue_number"`
-	CAM         string             `json:"cam"`
-	Company     string             `json:"company"`
-	Label       string             `json:"label"`
-	Grade       string             `json:"grade"`
-	Metrics     map[string]Metrics `json:"metrics"`
-	FMV         *float64           `json:"fmv"`
+// WithRequireDeadline makes every request fail with ErrNoDeadline unless its
+// context carries a deadline, catching accidentally unbounded calls. Methods
+// that do not take a context always use one without a deadline, so they
+// cannot be used with this option.
+func WithRequireDeadline() ClientOption {
+	return func(c *Client) error {
+		c.requireDeadline = true
+		return nil
+	}
 }
 
-// GetItemInsights retrieves insights for a specific item
-func (s *InsightsService) GetItemInsights(itemID int, grade string, company string, label string) (*ItemInsights, error) {
-	params := url.Values{}
-	params.Add("grade", grade)
-	if company != "" {
-		params.Add("company", company)
+// WithRandSource sets the source of randomness used by the client, such as
+// the jitter applied to retry backoff delays. It is mainly useful for making
+// that behavior reproducible in tests. Key generation does not use it:
+// GeneratePartnerSaleID is deterministic, so its ids are reproducible
+// whatever the source. The default source is seeded from crypto/rand. The
+// source is only used while holding a lock, so it does not need to be safe
+// for concurrent use.
+func WithRandSource(src rand.Source) ClientOption {
+	return func(c *Client) error {
+		if src == nil {
+			return errors.New("gocollect: rand source must not be nil")
+		}
+		c.rand = rand.New(src)
+		return nil
 	}
-	if label != "" {
-		params.Add("label", label)
+}
+
+// newSecureSource returns a math/rand source seeded from crypto/rand
+func newSecureSource() rand.Source {
+	var seed [8]byte
+	if _, err := crand.Read(seed[:]); err != nil {
+		return rand.NewSource(time.Now().UnixNano())
 	}
+	return rand.NewSource(int64(binary.LittleEndian.Uint64(seed[:])))
+}
 
-	path := fmt.Sprintf("/api/insights/v1/item/%d?%s", itemID, params.Encode())
-	req, err := s.client.newRequest("GET", path, nil)
-	if err != nil {
-		return nil, err
+// jitter returns a random duration in [d/2, d)
+func (c *Client) jitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
 	}
+	c.randMu.Lock()
+	defer c.randMu.Unlock()
+	half := d / 2
+	return half + time.Duration(c.rand.Int63n(int64(d-half)))
+}
 
-	insights := new(ItemInsights)
-	_, err = s.client.do(req, insights)
-	return insights, err
+// WithInsecureSkipVerify disables TLS certificate verification.
+//
+// WARNING: this is intended only for testing against local or ephemeral
+// environments with self-signed certificates. It makes the client vulnerable
+// to man-in-the-middle attacks and must never be used in production.
+func WithInsecureSkipVerify() ClientOption {
+	return func(c *Client) error {
+		return c.configureTransport(func(t *http.Transport) {
+			if t.TLSClientConfig == nil {
+				t.TLSClientConfig = &tls.Config{}
+			}
+			t.TLSClientConfig.InsecureSkipVerify = true
+		})
+	}
 }
 
-// GetItemInsightsByCGCID retrieves insights for a specific CGC item
-func (s *InsightsService) GetItemInsightsByCGCID(cgcID string, grade string, company string, label string) (*ItemInsights, error) {
-	params := url.Values{}
-	params.Add("grade", grade)
-	if company != "" {
-		params.Add("company", company)
+// WithMinTLSVersion sets the minimum TLS version accepted by the client, e.g.
+// tls.VersionTLS13. Like the other transport options, it configures a copy of
+// the HTTP client set so far: it applies on top of a preceding WithHTTPClient,
+// and is discarded by a later one.
+func WithMinTLSVersion(version uint16) ClientOption {
+	return func(c *Client) error {
+		if version < tls.VersionTLS10 || version > tls.VersionTLS13 {
+			return fmt.Errorf("gocollect: unsupported TLS version %#04x", version)
+		}
+		return c.configureTransport(func(t *http.Transport) {
+			if t.TLSClientConfig == nil {
+				t.TLSClientConfig = &tls.Config{}
+			}
+			t.TLSClientConfig.MinVersion = version
+		})
 	}
-	if label != "" {
-		params.Add("label", label)
+}
+
+// WithProxy routes all requests through the given HTTP(S) proxy
+func WithProxy(proxyURL string) ClientOption {
+	return func(c *Client) error {
+		parsedURL, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("gocollect: invalid proxy URL %q: %w", proxyURL, err)
+		}
+		if parsedURL.Scheme == "" || parsedURL.Host == "" {
+			return fmt.Errorf("gocollect: invalid proxy URL %q: scheme and host are required", proxyURL)
+		}
+		return c.configureTransport(func(t *http.Transport) {
+			t.Proxy = http.ProxyURL(parsedURL)
+		})
 	}
+}
 
-	path := fmt.Sprintf("/api/insights/v1/item/cgc-id/%s?%s", cgcID, params.Encode())
-	req, err := s.client.newRequest("GET", path, nil)
-	if err != nil {
-		return nil, err
+// WithProxyFromEnvironment routes requests through the proxy configured by
+// the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+func WithProxyFromEnvironment() ClientOption {
+	return func(c *Client) error {
+		return c.configureTransport(func(t *http.Transport) {
+			t.Proxy = http.ProxyFromEnvironment
+		})
 	}
+}
 
-	insights := new(ItemInsights)
-	_, err = s.client.do(req, insights)
-	return insights, err
+// WithConnectTimeout bounds the time spent establishing a connection to the
+// API, so an unreachable host fails fast. Reading the response is not
+// affected: the overall limit is still the context deadline or the Timeout
+// of the http.Client given to WithHTTPClient, and a connect timeout longer
+// than either has no effect. Apply it after WithHTTPClient.
+func WithConnectTimeout(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d <= 0 {
+			return fmt.Errorf("gocollect: connect timeout must be positive, got %s", d)
+		}
+		dialer := &net.Dialer{Timeout: d, KeepAlive: 30 * time.Second}
+		return c.configureTransport(func(t *http.Transport) {
+			t.DialContext = dialer.DialContext
+		})
+	}
 }
 
-// Common types for both SoldExamples and StagedSales
-type SaleFormat string
+// configureTransport applies fn to a private clone of the client's transport,
+// so options never modify http.DefaultTransport or a caller-supplied client
+func (c *Client) configureTransport(fn func(*http.Transport)) error {
+	base := c.client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t, ok := base.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("gocollect: cannot configure transport of type %T", base)
+	}
 
-const (
-	SaleFormatAuction    SaleFormat = "auction"
-	SaleFormatFixedPrice SaleFormat = "fixed_price"
-)
+	clone := t.Clone()
+	fn(clone)
 
-// SoldExamplesService handles communication with the sold examples related endpoints
-type SoldExamplesService struct {
-	client *Client
+	httpClient := *c.client
+	httpClient.Transport = clone
+	c.client = &httpClient
+	return nil
 }
 
-// SoldExample represents a sold collectible
-type SoldExample struct {
-	PartnerSaleID        string     `json:"partner_sale_id"`
-	CAM                  string     `json:"cam"`
-	Title                string     `json:"title"`
-	ImageURLs            []string   `json:"image_urls"`
-	GocollectItemID      *int       `json:"gocollect_item_id"`
-	CertificationCompany string     `json:"certification_company"`
-	CertificationKey     *string    `json:"certification_key"`
-	ListedPrice          *float64   `json:"listed_price"`
-	ListedAt             time.Time  `json:"listed_at"`
-	SoldPrice            float64    `json:"sold_price"`
-	SoldAt               time.Time  `json:"sold_at"`
-	URL                  string     `json:"url"`
-	Format               SaleFormat `json:"format"`
-	AuctionName          *string    `json:"auction_name"`
-	BidCount             *int       `json:"bid_count"`
-}
-
-// CreateSoldExample creates a new sold example
-func (s *SoldExamplesService) CreateSoldExample(example *SoldExample) error {
-	req, err := s.client.newRequest("POST", "/api/resources/v1/sold-examples", example)
-	if err != nil {
-		return err
+// formatByteSize formats n bytes for error messages, e.g. "1.5MB"
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
 	}
-
-	_, err = s.client.do(req, nil)
-	return err
+	value, suffix := float64(n)/unit, "KB"
+	if n >= unit*unit {
+		value, suffix = float64(n)/(unit*unit), "MB"
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0") + suffix
 }
 
-// GetSoldExample retrieves a specific sold example
-func (s *SoldExamplesService) GetSoldExample(partnerSaleID string) (*SoldExample, error) {
-	path := fmt.Sprintf("/api/resources/v1/sold-examples/%s", partnerSaleID)
-	req, err := s.client.newRequest("GET", path, nil)
+// newRequest creates a new API request
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	u, err := c.baseURL.Parse(path)
 	if err != nil {
 		return nil, err
 	}
 
-	var response struct {
-		Data SoldExample `json:"data"`
+	var buf io.ReadWriter
+	if body != nil {
+		encoded := new(bytes.Buffer)
+		err := c.newEncoder(encoded).Encode(body)
+		if err != nil {
+			return nil, err
+		}
+		if c.maxRequestBodySize > 0 && int64(encoded.Len()) > c.maxRequestBodySize {
+			return nil, fmt.Errorf("%w: payload %s exceeds %s limit", ErrRequestBodyTooLarge,
+				formatByteSize(int64(encoded.Len())), formatByteSize(c.maxRequestBodySize))
+		}
+		buf = encoded
 	}
-	_, err = s.client.do(req, &response)
-	return &response.Data, err
-}
-
-// StagedSalesService handles communication with the staged sales related endpoints
-type StagedSalesService struct {
-	client *Client
-}
 
-// StagedSale represents a staged sale
-type StagedSale struct {
-	PartnerSaleID        string     `json:"partner_sale_id"`
-	CAM                  string     `json:"cam"`
-	Title                string     `json:"title"`
-	IsActive             bool       `json:"is_active"`
-	ImageURLs            []string   `json:"image_urls"`
-	GocollectItemID      *int       `json:"gocollect_item_id"`
-	IsGraded             bool       `json:"is_graded"`
-	CertificationCompany string     `json:"certification_company"`
-	CertificationKey     *string    `json:"certification_key"`
-	ListedPrice          *float64   `json:"listed_price"`
-	Price                *float64   `json:"price"`
-	SoldAt               time.Time  `json:"sold_at"`
-	URL                  string     `json:"url"`
-	Format               SaleFormat `json:"format"`
-	AuctionName          *string    `json:"auction_name"`
-	EndsAt               *time.Time `json:"ends_at"`
-}
-
-// CreateStagedSale creates a new staged sale
-func (s *StagedSalesService) CreateStagedSale(sale *StagedSale) error {
-	req, err := s.client.newRequest("POST", "/api/resources/v1/staged-sales", sale)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = s.client.do(req, nil)
-	return err
+	if err := c.authorize(req); err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.compression {
+		req.Header.Set("Accept-Encoding", acceptEncoding())
+	}
+
+	return req, nil
 }
 
-// GetStagedSale retrieves a specific staged sale
-func (s *StagedSalesService) GetStagedSale(id string) (*StagedSale, error) {
-	path := fmt.Sprintf("/api/resources/v1/staged-sales/%s", id)
-	req, err := s.client.newRequest("GET", path, nil)
-	if err != nil {
-		return nil, err
+// do sends an API request and returns the response, retrying failed
+// attempts as configured by WithMaxRetries
+func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
+	if c.baseCtx != nil {
+		ctx, stop := mergeContexts(req.Context(), c.baseCtx)
+		defer stop()
+		req = req.WithContext(ctx)
 	}
 
-	var response struct {
-		Data StagedSale `json:"data"`
+	if timeout := requestConfigFrom(req.Context()).timeout; timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	ctx := req.Context()
+	if c.requireDeadline {
+		if _, ok := ctx.Deadline(); !ok {
+			return nil, ErrNoDeadline
+		}
+	}
+
+	if c.dryRun && isWriteMethod(req.Method) {
+		return c.dryRunResponse(req)
+	}
+
+	cfg := requestConfigFrom(ctx)
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		if err := c.waitForToken(ctx, req.URL.Path, cfg.priority); err != nil {
+			return nil, err
+		}
+
+		var resp *http.Response
+		err := c.breaker.allow()
+		if err == nil {
+			sent := time.Now()
+			resp, err = c.send(req, v)
+			c.observeAttempt(ctx, req, resp, time.Since(sent))
+			if ctx.Err() == nil {
+				c.breaker.record(isTransient(resp, err))
+			} else {
+				c.breaker.release()
+			}
+		}
+		if attempt >= c.maxRetries || !c.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay, delayErr := c.retryDelay(attempt, err)
+		if delayErr != nil {
+			return resp, delayErr
+		}
+		if c.maxElapsedTime > 0 && time.Since(start)+delay > c.maxElapsedTime {
+			return resp, err
+		}
+		c.logf("retrying %s %s in %s after error: %v", req.Method, req.URL, delay, err)
+		if waitErr := sleep(ctx, delay); waitErr != nil {
+			return resp, waitErr
+		}
+
+		req, err = rewindRequest(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send performs a single attempt of an API request
+func (c *Client) send(req *http.Request, v interface{}) (*http.Response, error) {
+	if c.inFlight != nil {
+		select {
+		case c.inFlight <- struct{}{}:
+			defer func() { <-c.inFlight }()
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	c.notifyDeprecation(req.URL.Path, resp)
+	if m := requestConfigFrom(req.Context()).metadata; m != nil {
+		*m = ResponseMetadata{
+			StatusCode: resp.StatusCode,
+			RequestID:  requestID(resp.Header),
+			Header:     resp.Header,
+		}
+	}
+
+	if c.compression {
+		if err := decompressBody(resp); err != nil {
+			return resp, err
+		}
+	}
+
+	if resp.StatusCode >= 400 {
+		return resp, c.responseError(resp)
+	}
+
+	if handler, ok := v.(responseHandler); ok {
+		return resp, handler.handleResponse(req.Context(), resp)
+	}
+	if v != nil && resp.StatusCode != http.StatusNoContent {
+		return resp, c.decodeResponse(req.Context(), resp, v)
+	}
+
+	return resp, nil
+}
+
+// responseHandler is implemented by values passed to do that read a
+// successful response themselves instead of having it decoded as JSON
+type responseHandler interface {
+	handleResponse(ctx context.Context, resp *http.Response) error
+}
+
+// decodeResponse decodes a successful JSON response into v, then checks
+// its enums and runs the post-processing hooks as the client is configured
+func (c *Client) decodeResponse(ctx context.Context, resp *http.Response, v interface{}) error {
+	if err := c.decodeBody(ctx, resp.Body, v); err != nil {
+		if c.schemaGuard {
+			err = schemaError(err)
+		}
+		return err
+	}
+	if c.strictEnums {
+		if err := checkEnums(reflect.ValueOf(v), ""); err != nil {
+			return err
+		}
+	}
+	c.postProcess(v)
+	return nil
+}
+
+// decodeBody decodes a JSON response body into v. An empty body leaves v
+// untouched.
+func (c *Client) decodeBody(ctx context.Context, body io.Reader, v interface{}) error {
+	if !c.bufferPooling && !c.lenientDecode && !c.captureRaw {
+		if err := json.NewDecoder(body).Decode(v); err != nil && err != io.EOF {
+			return err
+		}
+		return nil
+	}
+
+	buf := new(bytes.Buffer)
+	if c.bufferPooling {
+		buf = bufferPool.Get().(*bytes.Buffer)
+		buf.Reset()
+		defer func() {
+			if buf.Cap() <= maxPooledBufferSize {
+				bufferPool.Put(buf)
+			}
+		}()
+	}
+
+	if _, err := buf.ReadFrom(body); err != nil {
+		return err
+	}
+	data := bytes.TrimSpace(buf.Bytes())
+	if len(data) == 0 {
+		return nil
+	}
+	var err error
+	if c.lenientDecode {
+		err = c.decodeLenient(ctx, data, v)
+	} else {
+		err = json.Unmarshal(data, v)
+	}
+	if err == nil && c.captureRaw {
+		captureRawJSON(data, v)
+	}
+	return err
+}
+
+// ListOptions specifies the pagination parameters shared by list endpoints
+type ListOptions struct {
+	Page  int
+	Limit int
+	// Next is the NextURL of a previous page; when set it is requested as is
+	// and the page number and filters are ignored
+	Next string
+}
+
+// encode adds the pagination parameters to params, using defaultLimit when
+// no limit is set
+func (o ListOptions) encode(params url.Values, defaultLimit int) {
+	if o.Page > 0 {
+		params.Set("page", fmt.Sprintf("%d", o.Page))
+	}
+	if o.Limit <= 0 {
+		o.Limit = defaultLimit
+	}
+	if o.Limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", o.Limit))
+	}
+}
+
+// ListMeta describes the page of results returned by a list endpoint
+type ListMeta struct {
+	CurrentPage int `json:"current_page"`
+	LastPage    int `json:"last_page"`
+	PerPage     int `json:"per_page"`
+	Total       int `json:"total"`
+	// NextURL is the next page advertised by the response's Link header, if any
+	NextURL string `json:"-"`
+}
+
+// HasNextPage reports whether another page of results is available
+func (m *ListMeta) HasNextPage() bool {
+	return m != nil && (m.NextURL != "" || m.CurrentPage < m.LastPage)
+}
+
+// CollectiblesService handles communication with the collectible related endpoints
+type CollectiblesService struct {
+	client *Client
+
+	// bulkSearchUnsupported is set once the bulk search endpoint is found missing
+	bulkSearchUnsupported atomic.Bool
+
+	// slugs caches the item ids resolved by ResolveSlugs
+	slugs slugCache
+}
+
+// SearchItemsOptions represents the parameters for searching items
+type SearchItemsOptions struct {
+	Query string
+	CAM   string
+	// CAMs searches across several CAMs; it is combined with CAM if both are set
+	CAMs  []string
+	Limit int
+	// Page selects a page of Limit results, starting at 1; zero is the first page
+	Page int
+}
+
+// SearchItem represents a collectible item in search results
+type SearchItem struct {
+	ItemID             int     `json:"item_id"`
+	UUID               string  `json:"uuid"`
+	Slug               string  `json:"slug"`
+	Name               string  `json:"name"`
+	VariantOfItemID    *int    `json:"variant_of_item_id"`
+	VariantDescription *string `json:"variant_description"`
+
+	// Raw is the JSON the record was decoded from, set when WithCaptureRaw
+	// is enabled
+	Raw json.RawMessage `json:"-"`
+}
+
+// SearchItems searches for collectible items
+func (s *CollectiblesService) SearchItems(opts SearchItemsOptions) ([]SearchItem, error) {
+	return s.searchItems(context.Background(), opts)
+}
+
+func (s *CollectiblesService) searchItems(ctx context.Context, opts SearchItemsOptions) ([]SearchItem, error) {
+	params := url.Values{}
+	params.Add("query", opts.Query)
+	if opts.CAM != "" {
+		params.Add("cam", opts.CAM)
+	}
+	for _, cam := range opts.CAMs {
+		params.Add("cam", cam)
+	}
+	ListOptions{Page: opts.Page, Limit: opts.Limit}.encode(params, s.client.defaultLimit)
+
+	path := fmt.Sprintf("%s/item/search?%s", s.client.apiBase(APICollectibles), params.Encode())
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []SearchItem
+	_, err = s.client.do(req, &items)
+	return items, err
+}
+
+// SearchItemsCollect pages through the results of a search and returns them
+// all, up to maxItems. truncated reports whether results were left out
+// because of the cap. opts.Page is ignored and opts.Limit is the page size.
+func (s *CollectiblesService) SearchItemsCollect(ctx context.Context, opts SearchItemsOptions, maxItems int) (items []SearchItem, truncated bool, err error) {
+	if maxItems <= 0 {
+		return nil, false, fmt.Errorf("gocollect: maxItems must be positive, got %d", maxItems)
+	}
+	err = s.eachSearchItem(ctx, opts, func(item SearchItem) (bool, error) {
+		if len(items) == maxItems {
+			truncated = true
+			return false, nil
+		}
+		items = append(items, item)
+		return true, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return items, truncated, nil
+}
+
+// eachSearchItem pages through the results of a search, calling fn with each
+// item not already seen on an earlier page. It stops when the results are
+// exhausted, fn returns false or an error, or a page fails.
+func (s *CollectiblesService) eachSearchItem(ctx context.Context, opts SearchItemsOptions, fn func(SearchItem) (bool, error)) error {
+	pageSize := opts.Limit
+	if pageSize <= 0 {
+		pageSize = s.client.defaultLimit
+	}
+
+	seen := make(map[int]bool)
+	for opts.Page = 1; ; opts.Page++ {
+		page, err := s.searchItems(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		added := 0
+		for _, item := range page {
+			if seen[item.ItemID] {
+				continue
+			}
+			more, err := fn(item)
+			if err != nil || !more {
+				return err
+			}
+			seen[item.ItemID] = true
+			added++
+		}
+		// A short page is the last one; a page of repeats means the API
+		// does not page searches
+		if added == 0 || (pageSize > 0 && len(page) < pageSize) {
+			return nil
+		}
+	}
+}
+
+// Item represents the details of a collectible item
+type Item struct {
+	SearchItem
+
+	// KeyComments explains why the item is considered a key issue (e.g. a
+	// first appearance). It is empty for items without key notes.
+	KeyComments []string `json:"key_comments,omitempty"`
+	Notes       *string  `json:"notes,omitempty"`
+}
+
+// ResolveItemByCertification looks up the item a certified copy belongs to
+// from its certification company and key. An unknown certificate yields an
+// error matching ErrNotFound.
+func (s *CollectiblesService) ResolveItemByCertification(ctx context.Context, company string, key string) (*SearchItem, error) {
+	params := url.Values{}
+	params.Add("certification_company", company)
+	params.Add("certification_key", key)
+
+	path := fmt.Sprintf("%s/item/lookup?%s", s.client.apiBase(APICollectibles), params.Encode())
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	item := new(SearchItem)
+	_, err = s.client.do(req, item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetItem retrieves the details of a collectible item
+func (s *CollectiblesService) GetItem(ctx context.Context, itemID int) (*Item, error) {
+	path := fmt.Sprintf("%s/item/%d", s.client.apiBase(APICollectibles), itemID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	item := new(Item)
+	_, err = s.client.do(req, item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetSimilarItems retrieves items related to the given item, such as those
+// other collectors also viewed. An item without recommendations yields an
+// empty slice.
+func (s *CollectiblesService) GetSimilarItems(ctx context.Context, itemID int, limit int) ([]SearchItem, error) {
+	params := url.Values{}
+	ListOptions{Limit: limit}.encode(params, s.client.defaultLimit)
+
+	path := fmt.Sprintf("%s/item/%d/similar?%s", s.client.apiBase(APICollectibles), itemID, params.Encode())
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []SearchItem
+	_, err = s.client.do(req, &items)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []SearchItem{}
+	}
+	return items, nil
+}
+
+// ItemImage represents an image of a collectible item
+type ItemImage struct {
+	URL    string `json:"url"`
+	Type   string `json:"type"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// GetItemImages retrieves the images of an item. An item without images
+// yields an empty slice.
+func (s *CollectiblesService) GetItemImages(ctx context.Context, itemID int) ([]ItemImage, error) {
+	path := fmt.Sprintf("%s/item/%d/images", s.client.apiBase(APICollectibles), itemID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []ItemImage
+	_, err = s.client.do(req, &images)
+	if err != nil {
+		return nil, err
+	}
+	if images == nil {
+		images = []ItemImage{}
+	}
+	return images, nil
+}
+
+// InsightsService handles communication with the insights related endpoints
+type InsightsService struct {
+	client *Client
+
+	// bulkUnsupported is set once the bulk insights endpoint is found missing
+	bulkUnsupported atomic.Bool
+}
+
+// Metrics represents sales metrics for a specific time period
+type Metrics struct {
+	SoldCount    int     `json:"sold_count"`
+	LowPrice     float64 `json:"low_price"`
+	HighPrice    float64 `json:"high_price"`
+	AveragePrice float64 `json:"average_price"`
+}
+
+// ItemInsights represents insights for a collectible item
+type ItemInsights struct {
+	ItemID      int                `json:"item_id"`
+	Title       string             `json:"title"`
+	IssueNumber string             `json:"issue_number"`
+	CAM         string             `json:"cam"`
+	Company     string             `json:"company"`
+	Label       GradingLabel       `json:"label"`
+	Grade       string             `json:"grade"`
+	Metrics     map[string]Metrics `json:"metrics"`
+	FMV         *float64           `json:"fmv"`
+	// LastCalculatedAt is when the server last computed these insights; it
+	// is nil if the API did not report it
+	LastCalculatedAt *time.Time `json:"last_calculated_at"`
+
+	// Raw is the JSON the record was decoded from, set when WithCaptureRaw
+	// is enabled
+	Raw json.RawMessage `json:"-"`
+}
+
+// MetricsPeriod identifies the time window of a set of metrics, as used for
+// the keys of ItemInsights.Metrics
+type MetricsPeriod string
+
+const (
+	MetricsPeriod30Days  MetricsPeriod = "30"
+	MetricsPeriod90Days  MetricsPeriod = "90"
+	MetricsPeriod365Days MetricsPeriod = "365"
+	MetricsPeriodAllTime MetricsPeriod = "all"
+)
+
+// PeriodMetrics pairs metrics with the period they cover
+type PeriodMetrics struct {
+	Period  MetricsPeriod
+	Metrics Metrics
+}
+
+// SortedMetrics returns the metrics ordered from the shortest to the longest
+// window. Periods that are not a number of days, other than all-time, sort
+// last in name order.
+func (i *ItemInsights) SortedMetrics() []PeriodMetrics {
+	sorted := make([]PeriodMetrics, 0, len(i.Metrics))
+	for period, metrics := range i.Metrics {
+		sorted = append(sorted, PeriodMetrics{Period: MetricsPeriod(period), Metrics: metrics})
+	}
+
+	sort.SliceStable(sorted, func(a, b int) bool {
+		ra, rb := sorted[a].Period.rank(), sorted[b].Period.rank()
+		if ra != rb {
+			return ra < rb
+		}
+		return sorted[a].Period < sorted[b].Period
+	})
+	return sorted
+}
+
+// MetricsWithFallback returns the metrics of the prefer period if present,
+// otherwise those of the fallback period, along with the period used. ok is
+// false if neither is present.
+func (i ItemInsights) MetricsWithFallback(prefer, fallback MetricsPeriod) (m Metrics, period MetricsPeriod, ok bool) {
+	for _, p := range []MetricsPeriod{prefer, fallback} {
+		if metrics, found := i.Metrics[string(p)]; found {
+			return metrics, p, true
+		}
+	}
+	return Metrics{}, "", false
+}
+
+// rank orders periods by length: day counts first, then all-time, then
+// unknown periods
+func (p MetricsPeriod) rank() int {
+	if days, err := strconv.Atoi(string(p)); err == nil && days >= 0 {
+		return days
+	}
+	if p == MetricsPeriodAllTime {
+		return math.MaxInt32
+	}
+	return math.MaxInt32 + 1
+}
+
+// GradingLabel is the label a certification company gives a graded book,
+// e.g. CGC's Universal (blue) or Signature Series (yellow). Labels not
+// defined here can be used by converting a string.
+type GradingLabel string
+
+const (
+	LabelUniversal         GradingLabel = "Universal"
+	LabelSignatureSeries   GradingLabel = "Signature Series"
+	LabelSignature         GradingLabel = "Signature"
+	LabelVerifiedSignature GradingLabel = "Verified Signature"
+	LabelQualified         GradingLabel = "Qualified"
+	LabelRestored          GradingLabel = "Restored"
+	LabelConserved         GradingLabel = "Conserved"
+	LabelPedigree          GradingLabel = "Pedigree"
+)
+
+// DefaultCompanyLabels lists the grading labels known to be valid for each
+// certification company. Insights requests combining a listed company with a
+// label not in its list are rejected locally. Companies missing from the
+// table are not checked. Use WithCompanyLabels to allow additional labels.
+var DefaultCompanyLabels = map[string][]string{
+	"CGC":  {"Universal", "Signature Series", "Qualified", "Restored", "Conserved", "Pedigree"},
+	"CBCS": {"Universal", "Verified Signature", "Signature", "Restored", "Conserved"},
+	"PGX":  {"Universal", "Signature", "Restored"},
+}
+
+// defaultCompanyLabelSet builds the lookup table used to validate insights
+// requests from DefaultCompanyLabels
+func defaultCompanyLabelSet() map[string]map[string]bool {
+	set := make(map[string]map[string]bool, len(DefaultCompanyLabels))
+	for company, labels := range DefaultCompanyLabels {
+		addCompanyLabels(set, company, labels)
+	}
+	return set
+}
+
+// addCompanyLabels adds labels to the set for company, matching case-insensitively
+func addCompanyLabels(set map[string]map[string]bool, company string, labels []string) {
+	company = strings.ToUpper(company)
+	if set[company] == nil {
+		set[company] = make(map[string]bool)
+	}
+	for _, label := range labels {
+		set[company][strings.ToLower(label)] = true
+	}
+}
+
+// WithCompanyLabels allows additional labels for a certification company when
+// validating insights requests, e.g. a label introduced after this release
+func WithCompanyLabels(company string, labels ...string) ClientOption {
+	return func(c *Client) error {
+		if company == "" {
+			return errors.New("gocollect: company must not be empty")
+		}
+		addCompanyLabels(c.companyLabels, company, labels)
+		return nil
+	}
+}
+
+// validateCompanyLabel rejects known-invalid company and label combinations.
+// Without a company, the label must be valid for at least one company.
+func (c *Client) validateCompanyLabel(company string, label GradingLabel) error {
+	if label == "" {
+		return nil
+	}
+	key := strings.ToLower(string(label))
+	if company == "" {
+		for _, labels := range c.companyLabels {
+			if labels[key] {
+				return nil
+			}
+		}
+		return fmt.Errorf("gocollect: unknown label %q", label)
+	}
+	labels, ok := c.companyLabels[strings.ToUpper(company)]
+	if !ok || labels[key] {
+		return nil
+	}
+	return fmt.Errorf("gocollect: label %q is not valid for company %q", label, company)
+}
+
+// InsightsWindow is a relative time window for insights, aligned with the
+// keys of ItemInsights.Metrics
+type InsightsWindow string
+
+const (
+	Last30Days InsightsWindow = InsightsWindow(MetricsPeriod30Days)
+	Last90Days InsightsWindow = InsightsWindow(MetricsPeriod90Days)
+	LastYear   InsightsWindow = InsightsWindow(MetricsPeriod365Days)
+)
+
+// Period returns the key of ItemInsights.Metrics covering the window
+func (w InsightsWindow) Period() MetricsPeriod {
+	return MetricsPeriod(w)
+}
+
+// validate reports an error for windows the API does not support
+func (w InsightsWindow) validate() error {
+	switch w {
+	case "", Last30Days, Last90Days, LastYear:
+		return nil
+	}
+	return fmt.Errorf("gocollect: unsupported insights window %q", string(w))
+}
+
+// GradeQualifier is the grading tier of a sale, independent of the company's
+// label: a universal grade or one qualified by a defect, restoration or an
+// unwitnessed signature
+type GradeQualifier string
+
+const (
+	GradeQualifierUniversal GradeQualifier = "universal"
+	GradeQualifierQualified GradeQualifier = "qualified"
+	GradeQualifierRestored  GradeQualifier = "restored"
+	GradeQualifierSignature GradeQualifier = "signature"
+)
+
+// validate rejects unknown qualifiers; unset is valid
+func (q GradeQualifier) validate() error {
+	switch q {
+	case "", GradeQualifierUniversal, GradeQualifierQualified, GradeQualifierRestored, GradeQualifierSignature:
+		return nil
+	}
+	return fmt.Errorf("gocollect: unsupported grade qualifier %q", string(q))
+}
+
+// InsightsRequest identifies the insights to retrieve for one item, by
+// GoCollect item id or by CGC id
+type InsightsRequest struct {
+	ItemID  int          `json:"item_id,omitempty"`
+	CGCID   string       `json:"cgc_id,omitempty"`
+	Grade   string       `json:"grade"`
+	Company string       `json:"company,omitempty"`
+	Label   GradingLabel `json:"label,omitempty"`
+	// Window restricts the metrics to a relative time window; unset returns all
+	Window InsightsWindow `json:"window,omitempty"`
+	// GradedOnly restricts the metrics to slabbed sales when true and to raw
+	// (ungraded) sales when false; unset includes both. Grade applies either
+	// way, matched against the stated grade of raw sales.
+	GradedOnly *bool `json:"graded,omitempty"`
+	// Qualifier restricts the metrics to one grading tier; unset includes all
+	Qualifier GradeQualifier `json:"qualifier,omitempty"`
+}
+
+// validate checks the request locally before it is sent
+func (r InsightsRequest) validate(c *Client) error {
+	if err := c.validateCompanyLabel(r.Company, r.Label); err != nil {
+		return err
+	}
+	if err := r.Qualifier.validate(); err != nil {
+		return err
+	}
+	return r.Window.validate()
+}
+
+// GetItemInsights retrieves insights for a specific item
+func (s *InsightsService) GetItemInsights(itemID int, grade string, company string, label string) (*ItemInsights, error) {
+	return s.GetItemInsightsWithOptions(context.Background(), InsightsRequest{
+		ItemID:  itemID,
+		Grade:   grade,
+		Company: company,
+		Label:   GradingLabel(label),
+	})
+}
+
+// GetItemInsightsByCGCID retrieves insights for a specific CGC item
+func (s *InsightsService) GetItemInsightsByCGCID(cgcID string, grade string, company string, label string) (*ItemInsights, error) {
+	return s.GetItemInsightsWithOptions(context.Background(), InsightsRequest{
+		CGCID:   cgcID,
+		Grade:   grade,
+		Company: company,
+		Label:   GradingLabel(label),
+	})
+}
+
+// GetItemInsightsWithOptions retrieves insights for the item identified by r
+func (s *InsightsService) GetItemInsightsWithOptions(ctx context.Context, r InsightsRequest) (*ItemInsights, error) {
+	if err := r.validate(s.client); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("grade", r.Grade)
+	if r.Company != "" {
+		params.Add("company", r.Company)
+	}
+	if r.Label != "" {
+		params.Add("label", string(r.Label))
+	}
+	if r.Window != "" {
+		params.Add("period", string(r.Window))
+	}
+	if r.GradedOnly != nil {
+		params.Add("graded", strconv.FormatBool(*r.GradedOnly))
+	}
+	if r.Qualifier != "" {
+		params.Add("qualifier", string(r.Qualifier))
+	}
+
+	path := fmt.Sprintf("%s/item/%d?%s", s.client.apiBase(APIInsights), r.ItemID, params.Encode())
+	if r.CGCID != "" {
+		path = fmt.Sprintf("%s/item/cgc-id/%s?%s", s.client.apiBase(APIInsights), r.CGCID, params.Encode())
+	}
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.doInsights(req)
+}
+
+// Common types for both SoldExamples and StagedSales
+type SaleFormat string
+
+const (
+	SaleFormatAuction    SaleFormat = "auction"
+	SaleFormatFixedPrice SaleFormat = "fixed_price"
+)
+
+// ImageStatus is the outcome of the server fetching one submitted image URL
+type ImageStatus struct {
+	URL   string `json:"url"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// FailedImageURLs returns the URLs of the images the server could not fetch
+func FailedImageURLs(statuses []ImageStatus) []string {
+	var failed []string
+	for _, status := range statuses {
+		if !status.OK {
+			failed = append(failed, status.URL)
+		}
+	}
+	return failed
+}
+
+// SoldExampleStatus represents the review status of a sold example
+type SoldExampleStatus string
+
+const (
+	SoldExampleStatusActive    SoldExampleStatus = "active"
+	SoldExampleStatusDisputed  SoldExampleStatus = "disputed"
+	SoldExampleStatusRetracted SoldExampleStatus = "retracted"
+)
+
+// SoldExamplesService handles communication with the sold examples related endpoints
+type SoldExamplesService struct {
+	client *Client
+}
+
+// SoldExample represents a sold collectible
+type SoldExample struct {
+	PartnerSaleID        string               `json:"partner_sale_id" validate:"required"`
+	CAM                  string               `json:"cam" validate:"required"`
+	Title                string               `json:"title" validate:"required"`
+	ImageURLs            []string             `json:"image_urls"`
+	GocollectItemID      *int                 `json:"gocollect_item_id"`
+	CertificationCompany CertificationCompany `json:"certification_company"`
+	CertificationKey     *string              `json:"certification_key"`
+	ListedPrice          *float64             `json:"listed_price" validate:"min=0"`
+	ListedAt             time.Time            `json:"listed_at"`
+	SoldPrice            float64              `json:"sold_price" validate:"required,min=0"`
+	SoldAt               time.Time            `json:"sold_at" validate:"required"`
+	URL                  string               `json:"url" validate:"required,url"`
+	Format               SaleFormat           `json:"format" validate:"required,oneof=auction fixed_price"`
+	AuctionName          *string              `json:"auction_name"`
+	BidCount             *int                 `json:"bid_count" validate:"min=0"`
+	// OriginalPrice and OriginalCurrency record a sale made in another
+	// currency, with SoldPrice holding the amount converted to USD. Set both
+	// or neither; the currency is an ISO 4217 code such as "GBP".
+	OriginalPrice    *float64 `json:"original_price,omitempty"`
+	OriginalCurrency string   `json:"original_currency,omitempty"`
+	// Source names the marketplace the sale was made on, e.g. "ebay", for
+	// telling apart examples gathered from several marketplaces
+	Source string `json:"source,omitempty"`
+	// Status is set by the server; it is empty on examples being created
+	Status SoldExampleStatus `json:"status,omitempty"`
+	// ImageStatus reports whether the server could fetch each of ImageURLs,
+	// when the API returns it on creation
+	ImageStatus []ImageStatus `json:"image_status,omitempty"`
+
+	// Item is the matched item's summary when the API embeds it, otherwise nil
+	Item *SearchItem `json:"item,omitempty"`
+
+	// Raw is the JSON the record was decoded from, set when WithCaptureRaw
+	// is enabled
+	Raw json.RawMessage `json:"-"`
+}
+
+// CreateSoldExample validates and creates a new sold example. The
+// certification key is sent in its canonical form, see
+// NormalizeCertificationKey. example.ImageStatus is set from the response
+// when the API reports it, and example.PartnerSaleID when it is generated
+// (see WithGeneratedPartnerSaleIDs).
+func (s *SoldExamplesService) CreateSoldExample(example *SoldExample) error {
+	if example.PartnerSaleID == "" && s.client.autoPartnerIDs {
+		example.PartnerSaleID = GeneratePartnerSaleID(example)
+	}
+	if err := example.Validate(); err != nil {
+		return err
+	}
+
+	payload := *example
+	key, err := normalizedCertificationKey(payload.CertificationCompany, payload.CertificationKey)
+	if err != nil {
+		return err
+	}
+	payload.CertificationKey = key
+	if payload.GocollectItemID, err = s.client.resolveItemID(context.Background(), payload.GocollectItemID, payload.CertificationCompany, payload.CertificationKey); err != nil {
+		return err
+	}
+
+	req, err := s.client.newRequest(context.Background(), "POST", s.client.apiBase(APIResources)+"/sold-examples", &payload)
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Data struct {
+			ImageStatus []ImageStatus `json:"image_status"`
+		} `json:"data"`
+	}
+	if _, err := s.client.do(req, &response); err != nil {
+		return err
+	}
+	example.ImageStatus = response.Data.ImageStatus
+	return nil
+}
+
+// GetSoldExample retrieves a specific sold example
+func (s *SoldExamplesService) GetSoldExample(partnerSaleID string) (*SoldExample, error) {
+	return s.GetSoldExampleContext(context.Background(), partnerSaleID)
+}
+
+// GetSoldExampleContext is like GetSoldExample with a context. It revalidates
+// the copy in the client's ETag cache, if any (see WithETagCache).
+func (s *SoldExamplesService) GetSoldExampleContext(ctx context.Context, partnerSaleID string) (*SoldExample, error) {
+	return s.getSoldExample(ctx, partnerSaleID)
+}
+
+func (s *SoldExamplesService) getSoldExample(ctx context.Context, partnerSaleID string) (*SoldExample, error) {
+	path := fmt.Sprintf("%s/sold-examples/%s", s.client.apiBase(APIResources), partnerSaleID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data SoldExample `json:"data"`
+	}
+	_, err = s.client.getCached(req, &response)
+	return &response.Data, err
+}
+
+// RetractSoldExample flags a sold example as retracted, e.g. because the sale
+// turned out to be fraudulent, keeping it for auditing instead of deleting it
+func (s *SoldExamplesService) RetractSoldExample(ctx context.Context, partnerSaleID string, reason string) (*SoldExample, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, errors.New("gocollect: a reason is required to retract a sold example")
+	}
+
+	path := fmt.Sprintf("%s/sold-examples/%s/retract", s.client.apiBase(APIResources), partnerSaleID)
+	body := struct {
+		Reason string `json:"reason"`
+	}{reason}
+	req, err := s.client.newRequest(ctx, "POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data SoldExample `json:"data"`
 	}
 	_, err = s.client.do(req, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// SoldExampleResult holds the outcome of fetching one sold example in a batch
+type SoldExampleResult struct {
+	SoldExample *SoldExample
+	Err         error
+}
+
+// GetSoldExamplesByIDs retrieves multiple sold examples by partner sale id.
+// The result is keyed by id; a missing id yields an entry whose Err matches
+// ErrNotFound rather than failing the whole batch.
+func (s *SoldExamplesService) GetSoldExamplesByIDs(ctx context.Context, ids []string) map[string]SoldExampleResult {
+	ids = uniqueStrings(ids)
+	results := make([]SoldExampleResult, len(ids))
+	forEachConcurrently(ctx, len(ids), func(i int) {
+		example, err := s.getSoldExample(ctx, ids[i])
+		if err != nil {
+			results[i].Err = err
+			return
+		}
+		results[i].SoldExample = example
+	}, func(i int, err error) {
+		results[i].Err = err
+	})
+
+	byID := make(map[string]SoldExampleResult, len(ids))
+	for i, id := range ids {
+		byID[id] = results[i]
+	}
+	return byID
+}
+
+// DeleteSoldExample deletes a sold example
+func (s *SoldExamplesService) DeleteSoldExample(ctx context.Context, partnerSaleID string) error {
+	path := fmt.Sprintf("%s/sold-examples/%s", s.client.apiBase(APIResources), partnerSaleID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
+
+// DeleteSoldExamples deletes multiple sold examples by partner sale id with
+// bounded concurrency. A failure does not stop the batch; the result reports
+// whether each id was deleted, not found, or failed.
+func (s *SoldExamplesService) DeleteSoldExamples(ctx context.Context, ids []string) *BatchResult {
+	ids = uniqueStrings(ids)
+	result := &BatchResult{Results: make([]BatchItemResult, len(ids))}
+	forEachConcurrently(ctx, len(ids), func(i int) {
+		item := &result.Results[i]
+		item.ID = ids[i]
+		err := s.DeleteSoldExample(ctx, ids[i])
+		switch {
+		case err == nil:
+			item.Status = BatchStatusDeleted
+		case errors.Is(err, ErrNotFound):
+			item.Status = BatchStatusNotFound
+		default:
+			item.Status, item.Err = BatchStatusFailed, err
+		}
+	}, func(i int, err error) {
+		result.Results[i] = BatchItemResult{ID: ids[i], Status: BatchStatusFailed, Err: err}
+	})
+	return result
+}
+
+// ListSoldExamplesOptions represents the filters for listing sold examples
+type ListSoldExamplesOptions struct {
+	ItemID int
+	CAM    string
+	CAMs   []string
+	Grade  string
+	// GradedOnly selects slabbed (true) or raw (false) sales; unset returns
+	// both. Grade applies either way.
+	GradedOnly *bool
+	Format     SaleFormat
+	// Formats matches any of several formats; it is combined with Format if both are set
+	Formats []SaleFormat
+	// Source selects examples submitted with this source
+	Source string
+	// Sort is a field name, prefixed with "-" for descending order (e.g. "-sold_at")
+	Sort string
+
+	ListOptions
+}
+
+// ListSoldExamples lists sold examples matching the given filters
+func (s *SoldExamplesService) ListSoldExamples(ctx context.Context, opts ListSoldExamplesOptions) ([]SoldExample, *ListMeta, error) {
+	params := s.listSoldExamplesParams(opts)
+
+	path := listPath(s.client.apiBase(APIResources)+"/sold-examples", params, opts.ListOptions)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response struct {
+		Data []SoldExample `json:"data"`
+		Meta *ListMeta     `json:"meta"`
+	}
+	resp, err := s.client.do(req, &response)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response.Data, s.client.withLinkHeader(resp, response.Meta), nil
+}
+
+// listSoldExamplesParams encodes the list filters as query parameters
+func (s *SoldExamplesService) listSoldExamplesParams(opts ListSoldExamplesOptions) url.Values {
+	params := url.Values{}
+	if opts.ItemID > 0 {
+		params.Add("gocollect_item_id", fmt.Sprintf("%d", opts.ItemID))
+	}
+	if opts.CAM != "" {
+		params.Add("cam", opts.CAM)
+	}
+	for _, cam := range opts.CAMs {
+		params.Add("cam", cam)
+	}
+	if opts.Grade != "" {
+		params.Add("grade", opts.Grade)
+	}
+	if opts.GradedOnly != nil {
+		params.Add("graded", strconv.FormatBool(*opts.GradedOnly))
+	}
+	if opts.Format != "" {
+		params.Add("format", string(opts.Format))
+	}
+	for _, format := range opts.Formats {
+		params.Add("format", string(format))
+	}
+	if opts.Source != "" {
+		params.Add("source", opts.Source)
+	}
+	if opts.Sort != "" {
+		params.Add("sort", opts.Sort)
+	}
+	opts.ListOptions.encode(params, s.client.defaultLimit)
+	return params
+}
+
+// SoldExamplesForItemOptions represents the parameters for fetching an item's sold examples
+type SoldExamplesForItemOptions struct {
+	Grade string
+	// GradedOnly selects slabbed (true) or raw (false) sales; unset returns both
+	GradedOnly *bool
+	Limit      int
+}
+
+// GetSoldExamplesForItem retrieves the most recent sold examples for an item,
+// newest first
+func (s *SoldExamplesService) GetSoldExamplesForItem(ctx context.Context, itemID int, opts SoldExamplesForItemOptions) ([]SoldExample, error) {
+	examples, _, err := s.ListSoldExamples(ctx, ListSoldExamplesOptions{
+		ItemID:      itemID,
+		Grade:       opts.Grade,
+		GradedOnly:  opts.GradedOnly,
+		Sort:        "-sold_at",
+		ListOptions: ListOptions{Limit: opts.Limit},
+	})
+	return examples, err
+}
+
+// StagedSalesService handles communication with the staged sales related endpoints
+type StagedSalesService struct {
+	client *Client
+}
+
+// StagedSale represents a staged sale
+type StagedSale struct {
+	// ID is the server-assigned identifier, set on sales returned by the API
+	ID                   string               `json:"id,omitempty"`
+	PartnerSaleID        string               `json:"partner_sale_id" validate:"required"`
+	CAM                  string               `json:"cam" validate:"required"`
+	Title                string               `json:"title" validate:"required"`
+	IsActive             bool                 `json:"is_active"`
+	ImageURLs            []string             `json:"image_urls"`
+	GocollectItemID      *int                 `json:"gocollect_item_id"`
+	IsGraded             bool                 `json:"is_graded"`
+	CertificationCompany CertificationCompany `json:"certification_company"`
+	CertificationKey     *string              `json:"certification_key"`
+	ListedPrice          *float64             `json:"listed_price" validate:"min=0"`
+	Price                *float64             `json:"price" validate:"min=0"`
+	SoldAt               time.Time            `json:"sold_at"`
+	URL                  string               `json:"url" validate:"required,url"`
+	Format               SaleFormat           `json:"format" validate:"required,oneof=auction fixed_price"`
+	AuctionName          *string              `json:"auction_name"`
+	EndsAt               *time.Time           `json:"ends_at"`
+	// UpdatedAt is the time of the last change, set on sales returned by the API
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// ImageStatus reports whether the server could fetch each of ImageURLs,
+	// when the API returns it on creation
+	ImageStatus []ImageStatus `json:"image_status,omitempty"`
+
+	// Item is the matched item's summary when the API embeds it, otherwise nil
+	Item *SearchItem `json:"item,omitempty"`
+
+	// Version is the ETag the sale was returned with, for use with
+	// UpdateStagedSale. It is empty if the API did not send one.
+	Version string `json:"-"`
+
+	// Raw is the JSON the record was decoded from, set when WithCaptureRaw
+	// is enabled
+	Raw json.RawMessage `json:"-"`
+}
+
+// CreateStagedSale creates a new staged sale and returns it as stored by the
+// server. The certification key is sent in its canonical form, see
+// NormalizeCertificationKey. If the server responds without a body, the
+// returned sale is a copy of the input with ID taken from the Location header.
+func (s *StagedSalesService) CreateStagedSale(sale *StagedSale) (*StagedSale, error) {
+	return s.createStagedSale(context.Background(), sale)
+}
+
+func (s *StagedSalesService) createStagedSale(ctx context.Context, sale *StagedSale) (*StagedSale, error) {
+	if err := sale.Validate(); err != nil {
+		return nil, err
+	}
+	payload := *sale
+	key, err := normalizedCertificationKey(payload.CertificationCompany, payload.CertificationKey)
+	if err != nil {
+		return nil, err
+	}
+	payload.CertificationKey = key
+	if payload.GocollectItemID, err = s.client.resolveItemID(ctx, payload.GocollectItemID, payload.CertificationCompany, payload.CertificationKey); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "POST", s.client.apiBase(APIResources)+"/staged-sales", &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data *StagedSale `json:"data"`
+	}
+	resp, err := s.client.do(req, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response.Data != nil {
+		return response.Data, nil
+	}
+
+	created := payload
+	if location := resp.Header.Get("Location"); location != "" {
+		created.ID = location[strings.LastIndex(location, "/")+1:]
+	}
+	return &created, nil
+}
+
+// GetStagedSale retrieves a specific staged sale
+func (s *StagedSalesService) GetStagedSale(id string) (*StagedSale, error) {
+	path := fmt.Sprintf("%s/staged-sales/%s", s.client.apiBase(APIResources), id)
+	req, err := s.client.newRequest(context.Background(), "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Data StagedSale `json:"data"`
+	}
+	resp, err := s.client.do(req, &response)
+	if err == nil {
+		response.Data.Version = resp.Header.Get("ETag")
+	}
 	return &response.Data, err
 }
+
+// UpdateStagedSale replaces a staged sale. If expectedVersion is not empty,
+// it is sent as If-Match and the update fails with an error matching
+// ErrConflict when the sale was changed since that version was read; pass
+// the Version of the sale returned by GetStagedSale.
+func (s *StagedSalesService) UpdateStagedSale(ctx context.Context, id string, sale *StagedSale, expectedVersion string) (*StagedSale, error) {
+	if err := sale.Validate(); err != nil {
+		return nil, err
+	}
+	payload := *sale
+	key, err := normalizedCertificationKey(payload.CertificationCompany, payload.CertificationKey)
+	if err != nil {
+		return nil, err
+	}
+	payload.CertificationKey = key
+
+	if s.client.coalescer == nil || payload.PartnerSaleID == "" {
+		return s.updateStagedSale(ctx, id, &payload, expectedVersion)
+	}
+	sig, err := writeSignature(id, &payload, expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.coalescer.do(ctx, payload.PartnerSaleID, sig, expectedVersion, func(ctx context.Context) (*StagedSale, error) {
+		return s.updateStagedSale(ctx, id, &payload, expectedVersion)
+	})
+}
+
+// updateStagedSale sends a staged sale update
+func (s *StagedSalesService) updateStagedSale(ctx context.Context, id string, payload *StagedSale, expectedVersion string) (*StagedSale, error) {
+	path := fmt.Sprintf("%s/staged-sales/%s", s.client.apiBase(APIResources), id)
+	req, err := s.client.newRequest(ctx, "PUT", path, payload)
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion != "" {
+		req.Header.Set("If-Match", expectedVersion)
+	}
+
+	var response struct {
+		Data StagedSale `json:"data"`
+	}
+	resp, err := s.client.do(req, &response)
+	if err != nil {
+		return nil, err
+	}
+	response.Data.Version = resp.Header.Get("ETag")
+	return &response.Data, nil
+}
+
+// PatchStagedSale partially updates a staged sale using a JSON merge patch.
+// Only the fields present in patch are sent; keys must be JSON field names of
+// StagedSale and a nil value clears the field on the server.
+func (s *StagedSalesService) PatchStagedSale(ctx context.Context, id string, patch map[string]interface{}) (*StagedSale, error) {
+	if len(patch) == 0 {
+		return nil, errors.New("gocollect: empty patch")
+	}
+	if err := validatePatchFields(reflect.TypeOf(StagedSale{}), patch); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/staged-sales/%s", s.client.apiBase(APIResources), id)
+	req, err := s.client.newRequest(ctx, "PATCH", path, patch)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+
+	var response struct {
+		Data StagedSale `json:"data"`
+	}
+	resp, err := s.client.do(req, &response)
+	if err != nil {
+		return nil, err
+	}
+	response.Data.Version = resp.Header.Get("ETag")
+	return &response.Data, nil
+}
+
+// validatePatchFields ensures every key in patch names a JSON field of t
+func validatePatchFields(t reflect.Type, patch map[string]interface{}) error {
+	known := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			known[name] = true
+		}
+	}
+
+	var unknown []string
+	for key := range patch {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("gocollect: unknown %s patch fields: %s", t.Name(), strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// SetPriceFromFMV sets Price to the given FMV marked up by markupPct percent,
+// rounded up to a sensible asking increment
+func (s *StagedSale) SetPriceFromFMV(fmv *float64, markupPct float64) error {
+	if fmv == nil {
+		return errors.New("gocollect: cannot price staged sale without an FMV")
+	}
+	if *fmv <= 0 {
+		return fmt.Errorf("gocollect: invalid FMV %.2f", *fmv)
+	}
+
+	price := *fmv * (1 + markupPct/100)
+	if price <= 0 {
+		return fmt.Errorf("gocollect: markup of %.2f%% yields a non-positive price", markupPct)
+	}
+
+	increment := priceIncrement(price)
+	price = math.Ceil(price/increment) * increment
+	s.Price = &price
+	return nil
+}
+
+// priceIncrement returns the rounding step used for asking prices
+func priceIncrement(price float64) float64 {
+	switch {
+	case price < 100:
+		return 1
+	case price < 1000:
+		return 5
+	case price < 10000:
+		return 25
+	default:
+		return 100
+	}
+}
+
+// ListStagedSalesOptions represents the filters for listing staged sales
+type ListStagedSalesOptions struct {
+	CAM      string
+	CAMs     []string
+	IsActive *bool
+	Format   SaleFormat
+	Formats  []SaleFormat
+	// EndsBefore matches sales whose auction ends before the given time
+	EndsBefore time.Time
+	// Sort is a field name, prefixed with "-" for descending order (e.g. "ends_at")
+	Sort string
+
+	ListOptions
+
+	// updatedSince is set by ListStagedSalesChangedSince
+	updatedSince time.Time
+}
+
+// ListStagedSales lists staged sales matching the given filters
+func (s *StagedSalesService) ListStagedSales(ctx context.Context, opts ListStagedSalesOptions) ([]StagedSale, *ListMeta, error) {
+	params := url.Values{}
+	if opts.CAM != "" {
+		params.Add("cam", opts.CAM)
+	}
+	for _, cam := range opts.CAMs {
+		params.Add("cam", cam)
+	}
+	if opts.IsActive != nil {
+		params.Add("is_active", strconv.FormatBool(*opts.IsActive))
+	}
+	if opts.Format != "" {
+		params.Add("format", string(opts.Format))
+	}
+	for _, format := range opts.Formats {
+		params.Add("format", string(format))
+	}
+	if !opts.EndsBefore.IsZero() {
+		params.Add("ends_before", opts.EndsBefore.UTC().Format(time.RFC3339))
+	}
+	if !opts.updatedSince.IsZero() {
+		params.Add("updated_since", opts.updatedSince.UTC().Format(time.RFC3339Nano))
+	}
+	if opts.Sort != "" {
+		params.Add("sort", opts.Sort)
+	}
+	opts.ListOptions.encode(params, s.client.defaultLimit)
+
+	path := listPath(s.client.apiBase(APIResources)+"/staged-sales", params, opts.ListOptions)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response struct {
+		Data []StagedSale `json:"data"`
+		Meta *ListMeta    `json:"meta"`
+	}
+	resp, err := s.client.do(req, &response)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response.Data, s.client.withLinkHeader(resp, response.Meta), nil
+}
+
+// ChangeCursor marks how far an incremental sync with
+// ListStagedSalesChangedSince has got. The zero value starts from the
+// beginning; ChangeCursor{Since: t} starts from sales changed at or after t.
+// Store the returned cursor, e.g. as JSON, and pass it to the next sync.
+type ChangeCursor struct {
+	// Since is the latest change time seen
+	Since time.Time `json:"since"`
+	// SeenIDs are the ids of the sales already returned that changed
+	// exactly at Since, so that other sales changed at that same time are
+	// still returned by the next sync and these are not returned again
+	SeenIDs []string `json:"seen_ids,omitempty"`
+}
+
+// ListStagedSalesChangedSince retrieves every staged sale matching opts that
+// changed since the cursor, for incremental syncs, along with the cursor to
+// pass to the next sync; it is cursor itself if nothing changed. Sales the
+// API returns without an update time cannot be placed in the sync and are
+// skipped. Sales are also filtered locally, so the result is the same if the
+// API ignores the updated_since filter, only slower. opts.Page and opts.Sort
+// are ignored.
+func (s *StagedSalesService) ListStagedSalesChangedSince(ctx context.Context, cursor ChangeCursor, opts ListStagedSalesOptions) ([]StagedSale, ChangeCursor, error) {
+	opts.updatedSince = cursor.Since
+	opts.Sort = "updated_at"
+	opts.Page = 0
+	opts.Next = ""
+
+	seen := make(map[string]bool, len(cursor.SeenIDs))
+	for _, id := range cursor.SeenIDs {
+		seen[id] = true
+	}
+
+	var changed []StagedSale
+	next := ChangeCursor{Since: cursor.Since, SeenIDs: append([]string(nil), cursor.SeenIDs...)}
+	for {
+		sales, meta, err := s.ListStagedSales(ctx, opts)
+		if err != nil {
+			return nil, cursor, err
+		}
+		for _, sale := range sales {
+			if sale.UpdatedAt == nil || sale.UpdatedAt.Before(cursor.Since) {
+				continue
+			}
+			id := stagedSaleKey(sale)
+			if sale.UpdatedAt.Equal(cursor.Since) && seen[id] {
+				continue
+			}
+			changed = append(changed, sale)
+
+			switch {
+			case sale.UpdatedAt.After(next.Since):
+				next = ChangeCursor{Since: *sale.UpdatedAt, SeenIDs: []string{id}}
+			case sale.UpdatedAt.Equal(next.Since):
+				next.SeenIDs = append(next.SeenIDs, id)
+			}
+		}
+		if !meta.HasNextPage() {
+			break
+		}
+		opts.nextPage(meta)
+	}
+	return changed, next, nil
+}
+
+// stagedSaleKey identifies a staged sale by its server id, or by its partner
+// sale id for sales returned without one
+func stagedSaleKey(sale StagedSale) string {
+	if sale.ID != "" {
+		return sale.ID
+	}
+	return sale.PartnerSaleID
+}
+
+// GetStagedSalesEndingSoon retrieves the active auctions ending within the
+// given duration from now, soonest first. Sales without an end time are skipped.
+func (s *StagedSalesService) GetStagedSalesEndingSoon(ctx context.Context, within time.Duration) ([]StagedSale, error) {
+	now := time.Now()
+	deadline := now.Add(within)
+	active := true
+	opts := ListStagedSalesOptions{
+		IsActive:   &active,
+		Format:     SaleFormatAuction,
+		EndsBefore: deadline,
+		Sort:       "ends_at",
+	}
+
+	var sales []StagedSale
+	for {
+		batch, meta, err := s.ListStagedSales(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, sale := range batch {
+			if sale.EndsAt == nil || sale.EndsAt.Before(now) || sale.EndsAt.After(deadline) {
+				continue
+			}
+			if sale.IsActive && sale.Format == SaleFormatAuction {
+				sales = append(sales, sale)
+			}
+		}
+		if !meta.HasNextPage() {
+			break
+		}
+		opts.nextPage(meta)
+	}
+
+	sort.SliceStable(sales, func(i, j int) bool {
+		return sales[i].EndsAt.Before(*sales[j].EndsAt)
+	})
+	return sales, nil
+}
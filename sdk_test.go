@@ -0,0 +1,134 @@
+package gocollect
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestClient returns a client sending its requests to a test server
+// handled by handler
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient("test-token", append([]ClientOption{WithBaseURL(srv.URL)}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+func TestRepeatedQueryParams(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		call func(ctx context.Context, c *Client) error
+		want string
+	}{
+		{
+			name: "search CAMs",
+			body: `[]`,
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.Collectibles.searchItems(ctx, SearchItemsOptions{Query: "hulk", CAM: "Comics", CAMs: []string{"Magazines", "Cards"}, Limit: 5})
+				return err
+			},
+			want: "cam=Comics&cam=Magazines&cam=Cards&limit=5&query=hulk",
+		},
+		{
+			name: "sold examples CAMs and formats",
+			body: `{"data":[]}`,
+			call: func(ctx context.Context, c *Client) error {
+				_, _, err := c.SoldExamples.ListSoldExamples(ctx, ListSoldExamplesOptions{
+					CAMs:        []string{"Comics", "Cards"},
+					Formats:     []SaleFormat{SaleFormatAuction, SaleFormatFixedPrice},
+					ListOptions: ListOptions{Limit: 10},
+				})
+				return err
+			},
+			want: "cam=Comics&cam=Cards&format=auction&format=fixed_price&limit=10",
+		},
+		{
+			name: "staged sales CAM, CAMs, format and formats",
+			body: `{"data":[]}`,
+			call: func(ctx context.Context, c *Client) error {
+				_, _, err := c.StagedSales.ListStagedSales(ctx, ListStagedSalesOptions{
+					CAM:         "Comics",
+					CAMs:        []string{"Cards"},
+					Format:      SaleFormatAuction,
+					Formats:     []SaleFormat{SaleFormatFixedPrice},
+					ListOptions: ListOptions{Limit: 10},
+				})
+				return err
+			},
+			want: "cam=Comics&cam=Cards&format=auction&format=fixed_price&limit=10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.RawQuery
+				w.Write([]byte(tt.body))
+			})
+			if err := tt.call(context.Background(), c); err != nil {
+				t.Fatalf("call: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("query = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// staticTransport answers every request with status 200 and body
+type staticTransport struct {
+	body []byte
+}
+
+func (t staticTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+func BenchmarkSendDecode(b *testing.B) {
+	var items []string
+	for i := 0; i < 200; i++ {
+		items = append(items, fmt.Sprintf(`{"item_id":%d,"uuid":"uuid-%d","slug":"item-%d","name":"Item #%d"}`, i, i, i, i))
+	}
+	body := []byte("[" + strings.Join(items, ",") + "]")
+
+	for _, pooling := range []bool{false, true} {
+		b.Run(fmt.Sprintf("pooling=%t", pooling), func(b *testing.B) {
+			c, err := NewClient("test-token",
+				WithHTTPClient(&http.Client{Transport: staticTransport{body: body}}),
+				WithBufferPooling(pooling))
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				req, err := c.newRequest(context.Background(), "GET", "/api/collectibles/v1/item/search", nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+				var results []SearchItem
+				if _, err := c.send(req, &results); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package gocollect
+
+import "context"
+
+// SearchResult holds the outcome of one query in a multi-query search
+type SearchResult struct {
+	Items []SearchItem
+	Err   error
+}
+
+// SearchItemsMultiQuery runs several searches sharing the CAM and limit of
+// opts; opts.Query is ignored. The result is keyed by query, and a failed
+// query is reported in its entry's Err without failing the others. The
+// searches are sent in one POST request, or as concurrent GET requests if
+// the API does not provide the bulk endpoint.
+func (s *CollectiblesService) SearchItemsMultiQuery(ctx context.Context, queries []string, opts SearchItemsOptions) (map[string]SearchResult, error) {
+	queries = uniqueStrings(queries)
+	if len(queries) == 0 {
+		return map[string]SearchResult{}, nil
+	}
+
+	if !s.bulkSearchUnsupported.Load() {
+		results, err := s.postSearchItemsBulk(ctx, queries, opts)
+		if !isEndpointMissing(err) {
+			return results, err
+		}
+		s.bulkSearchUnsupported.Store(true)
+	}
+
+	return s.searchItemsConcurrently(ctx, queries, opts), nil
+}
+
+func (s *CollectiblesService) postSearchItemsBulk(ctx context.Context, queries []string, opts SearchItemsOptions) (map[string]SearchResult, error) {
+	body := struct {
+		Queries []string `json:"queries"`
+		CAMs    []string `json:"cam,omitempty"`
+		Limit   int      `json:"limit,omitempty"`
+	}{Queries: queries, Limit: opts.Limit}
+	if opts.CAM != "" {
+		body.CAMs = append(body.CAMs, opts.CAM)
+	}
+	body.CAMs = append(body.CAMs, opts.CAMs...)
+	if body.Limit <= 0 {
+		body.Limit = s.client.defaultLimit
+	}
+
+	req, err := s.client.newRequest(markIdempotent(ctx), "POST", s.client.apiBase(APICollectibles)+"/item/search/bulk", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response map[string][]SearchItem
+	if _, err := s.client.do(req, &response); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]SearchResult, len(queries))
+	for _, query := range queries {
+		results[query] = SearchResult{Items: response[query]}
+	}
+	return results, nil
+}
+
+func (s *CollectiblesService) searchItemsConcurrently(ctx context.Context, queries []string, opts SearchItemsOptions) map[string]SearchResult {
+	found := make([]SearchResult, len(queries))
+	forEachConcurrently(ctx, len(queries), func(i int) {
+		queryOpts := opts
+		queryOpts.Query = queries[i]
+		items, err := s.searchItems(ctx, queryOpts)
+		found[i] = SearchResult{Items: items, Err: err}
+	}, func(i int, err error) {
+		found[i].Err = err
+	})
+
+	results := make(map[string]SearchResult, len(queries))
+	for i, query := range queries {
+		results[query] = found[i]
+	}
+	return results
+}
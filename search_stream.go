@@ -0,0 +1,39 @@
+package gocollect
+
+import "context"
+
+// SearchItemsChan pages through the results of a search in the background,
+// sending each item on the returned item channel. Both channels are closed
+// when the results are exhausted, the search fails, or ctx is done; a
+// failure or ctx's error is sent on the error channel first.
+//
+// The item channel is unbuffered: the next item, and so the next page, is
+// only fetched once the previous item has been received, so a slow consumer
+// slows paging rather than letting results pile up in memory. Callers that
+// stop receiving early must cancel ctx so the background goroutine exits.
+func (s *CollectiblesService) SearchItemsChan(ctx context.Context, opts SearchItemsOptions) (<-chan SearchItem, <-chan error) {
+	items := make(chan SearchItem)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(items)
+		if err := s.streamSearchItems(ctx, opts, items); err != nil {
+			errc <- err
+		}
+	}()
+	return items, errc
+}
+
+// streamSearchItems sends the results of a search on items, page by page,
+// skipping items repeated across pages
+func (s *CollectiblesService) streamSearchItems(ctx context.Context, opts SearchItemsOptions, items chan<- SearchItem) error {
+	return s.eachSearchItem(ctx, opts, func(item SearchItem) (bool, error) {
+		select {
+		case items <- item:
+			return true, nil
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	})
+}
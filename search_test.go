@@ -0,0 +1,104 @@
+package gocollect
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// searchPages serves pages of item ids for a search, by page number
+func searchPages(t *testing.T, pages map[int][]int) *Client {
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		items := []SearchItem{}
+		for _, id := range pages[page] {
+			items = append(items, SearchItem{ItemID: id})
+		}
+		json.NewEncoder(w).Encode(items)
+	})
+}
+
+func TestSearchItemsPaging(t *testing.T) {
+	tests := []struct {
+		name          string
+		pages         map[int][]int
+		maxItems      int
+		want          []int
+		wantTruncated bool
+	}{
+		{
+			name:     "short page ends the results",
+			pages:    map[int][]int{1: {1, 2}, 2: {3}, 3: {4}},
+			maxItems: 10,
+			want:     []int{1, 2, 3},
+		},
+		{
+			name:     "page of repeats ends the results",
+			pages:    map[int][]int{1: {1, 2}, 2: {1, 2}, 3: {3, 4}},
+			maxItems: 10,
+			want:     []int{1, 2},
+		},
+		{
+			name:     "repeats across pages are skipped",
+			pages:    map[int][]int{1: {1, 2}, 2: {2, 3}},
+			maxItems: 10,
+			want:     []int{1, 2, 3},
+		},
+		{
+			name:          "cap truncates the results",
+			pages:         map[int][]int{1: {1, 2}, 2: {3, 4}},
+			maxItems:      3,
+			want:          []int{1, 2, 3},
+			wantTruncated: true,
+		},
+		{
+			name:     "cap reached with the last item",
+			pages:    map[int][]int{1: {1, 2}, 2: {3}},
+			maxItems: 3,
+			want:     []int{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := SearchItemsOptions{Query: "hulk", Limit: 2}
+
+			items, truncated, err := searchPages(t, tt.pages).Collectibles.SearchItemsCollect(context.Background(), opts, tt.maxItems)
+			if err != nil {
+				t.Fatalf("SearchItemsCollect: %v", err)
+			}
+			if got := itemIDs(items); !slices.Equal(got, tt.want) || truncated != tt.wantTruncated {
+				t.Errorf("SearchItemsCollect = %v, truncated %t, want %v, truncated %t", got, truncated, tt.want, tt.wantTruncated)
+			}
+
+			if tt.wantTruncated {
+				return
+			}
+			ch, errc := searchPages(t, tt.pages).Collectibles.SearchItemsChan(context.Background(), opts)
+			var streamed []SearchItem
+			for item := range ch {
+				streamed = append(streamed, item)
+			}
+			if err := <-errc; err != nil {
+				t.Fatalf("SearchItemsChan: %v", err)
+			}
+			if got := itemIDs(streamed); !slices.Equal(got, tt.want) {
+				t.Errorf("SearchItemsChan = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func itemIDs(items []SearchItem) []int {
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ItemID
+	}
+	return ids
+}
@@ -0,0 +1,118 @@
+package gocollect
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// slugCacheSize is the number of resolved slugs kept by ResolveSlugs
+const slugCacheSize = 1024
+
+// ResolveSlugs resolves item slugs to GoCollect item ids. Slugs resolved
+// before are answered from an in-memory cache of the most recently used
+// ones; the others are looked up with bounded concurrency. Unknown slugs are
+// left out of the map. Any other failure is returned as the error.
+func (s *CollectiblesService) ResolveSlugs(ctx context.Context, slugs []string) (map[string]int, error) {
+	ids := make(map[string]int, len(slugs))
+	var missing []string
+	for _, slug := range uniqueStrings(slugs) {
+		if id, ok := s.slugs.get(slug); ok {
+			ids[slug] = id
+		} else {
+			missing = append(missing, slug)
+		}
+	}
+
+	var mu sync.Mutex
+	var firstErr error
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	forEachConcurrently(ctx, len(missing), func(i int) {
+		item, err := s.getItemBySlug(ctx, missing[i])
+		if errors.Is(err, ErrNotFound) {
+			return
+		}
+		if err != nil {
+			fail(err)
+			return
+		}
+		s.slugs.add(missing[i], item.ItemID)
+		mu.Lock()
+		ids[missing[i]] = item.ItemID
+		mu.Unlock()
+	}, func(i int, err error) {
+		fail(err)
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return ids, nil
+}
+
+// getItemBySlug retrieves the search record of the item with the given slug
+func (s *CollectiblesService) getItemBySlug(ctx context.Context, slug string) (*SearchItem, error) {
+	path := fmt.Sprintf("%s/item/slug/%s", s.client.apiBase(APICollectibles), url.PathEscape(slug))
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	item := new(SearchItem)
+	_, err = s.client.do(req, item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// slugCache maps slugs to item ids, evicting the least recently used entry
+// beyond slugCacheSize. The zero value is ready to use.
+type slugCache struct {
+	mu      sync.Mutex
+	order   list.List
+	entries map[string]*list.Element
+}
+
+type slugEntry struct {
+	slug   string
+	itemID int
+}
+
+func (c *slugCache) get(slug string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[slug]
+	if !ok {
+		return 0, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*slugEntry).itemID, true
+}
+
+func (c *slugCache) add(slug string, itemID int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[slug]; ok {
+		e.Value.(*slugEntry).itemID = itemID
+		c.order.MoveToFront(e)
+		return
+	}
+	if c.entries == nil {
+		c.entries = make(map[string]*list.Element)
+	}
+	c.entries[slug] = c.order.PushFront(&slugEntry{slug: slug, itemID: itemID})
+	if c.order.Len() > slugCacheSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*slugEntry).slug)
+	}
+}
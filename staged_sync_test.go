@@ -0,0 +1,57 @@
+package gocollect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestListStagedSalesChangedSince(t *testing.T) {
+	t1 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	body := `{"data":[
+		{"id":"a","updated_at":"2024-05-01T12:00:00Z"},
+		{"id":"b"}
+	]}`
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+	ctx := context.Background()
+
+	changed, cursor, err := c.StagedSales.ListStagedSalesChangedSince(ctx, ChangeCursor{}, ListStagedSalesOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 1 || changed[0].ID != "a" {
+		t.Fatalf("first sync = %+v, want only sale a", changed)
+	}
+	if !cursor.Since.Equal(t1) || len(cursor.SeenIDs) != 1 {
+		t.Fatalf("cursor = %+v", cursor)
+	}
+
+	// A sale written later with the same timestamp is still picked up, and
+	// the one already returned is not repeated
+	body = `{"data":[
+		{"id":"a","updated_at":"2024-05-01T12:00:00Z"},
+		{"id":"c","updated_at":"2024-05-01T12:00:00Z"},
+		{"id":"b"}
+	]}`
+	changed, cursor, err = c.StagedSales.ListStagedSalesChangedSince(ctx, cursor, ListStagedSalesOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 1 || changed[0].ID != "c" {
+		t.Fatalf("second sync = %+v, want only sale c", changed)
+	}
+	if len(cursor.SeenIDs) != 2 {
+		t.Errorf("cursor ids = %v, want a and c", cursor.SeenIDs)
+	}
+
+	changed, _, err = c.StagedSales.ListStagedSalesChangedSince(ctx, cursor, ListStagedSalesOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("third sync = %+v, want nothing", changed)
+	}
+}
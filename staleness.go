@@ -0,0 +1,13 @@
+package gocollect
+
+import "time"
+
+// IsStale reports whether the insights were calculated more than maxAge
+// ago. Insights without a calculation time are reported stale, since their
+// freshness is unknown.
+func (i ItemInsights) IsStale(maxAge time.Duration) bool {
+	if i.LastCalculatedAt == nil {
+		return true
+	}
+	return time.Since(*i.LastCalculatedAt) > maxAge
+}
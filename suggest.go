@@ -0,0 +1,49 @@
+package gocollect
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MinSuggestPrefixLength is the shortest prefix Suggest sends to the API;
+// shorter prefixes return no suggestions without a request
+const MinSuggestPrefixLength = 2
+
+// Suggestion is a type-ahead completion for a search prefix
+type Suggestion struct {
+	Text   string `json:"text"`
+	ItemID int    `json:"item_id"`
+}
+
+// Suggest retrieves lightweight completions for a search prefix, for
+// type-ahead search boxes. It is cheaper than SearchItems; cancel ctx to
+// abandon the request when the user keeps typing. limit <= 0 uses the API's
+// default.
+func (s *CollectiblesService) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	prefix = strings.TrimSpace(prefix)
+	if utf8.RuneCountInString(prefix) < MinSuggestPrefixLength {
+		return []Suggestion{}, nil
+	}
+
+	params := url.Values{}
+	params.Add("query", prefix)
+	if limit > 0 {
+		params.Add("limit", strconv.Itoa(limit))
+	}
+
+	path := fmt.Sprintf("%s/item/suggest?%s", s.client.apiBase(APICollectibles), params.Encode())
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []Suggestion{}
+	if _, err := s.client.do(req, &suggestions); err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
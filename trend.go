@@ -0,0 +1,60 @@
+package gocollect
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TrendSummary holds the headline numbers of an item's sales trend. A nil
+// field means the data needed to compute it was unavailable.
+type TrendSummary struct {
+	ItemID int
+	Grade  string
+	FMV    *float64
+	// Average30Days and Average90Days are the average sold prices over the
+	// last 30 and 90 days; nil when there were no sales in the window
+	Average30Days *float64
+	Average90Days *float64
+	// YearOverYearPct is the percent change of the FMV over the last year
+	YearOverYearPct *float64
+}
+
+// GetItemTrendSummary retrieves the headline numbers of an item's sales
+// trend. The averages come from the item's insights and the year-over-year
+// change from its FMV history, so this makes two requests.
+func (s *InsightsService) GetItemTrendSummary(ctx context.Context, itemID int, grade string) (*TrendSummary, error) {
+	insights, err := s.GetItemInsightsWithOptions(ctx, InsightsRequest{ItemID: itemID, Grade: grade})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &TrendSummary{
+		ItemID:        itemID,
+		Grade:         grade,
+		FMV:           insights.FMV,
+		Average30Days: averagePrice(insights, MetricsPeriod30Days),
+		Average90Days: averagePrice(insights, MetricsPeriod90Days),
+	}
+
+	now := time.Now()
+	cmp, err := s.CompareItemInsights(ctx, itemID, grade, now.AddDate(-1, 0, 0), now)
+	switch {
+	case err == nil:
+		summary.YearOverYearPct = cmp.FMVChangePct
+	case !errors.Is(err, ErrNotFound):
+		return nil, err
+	}
+	return summary, nil
+}
+
+// averagePrice returns the average sold price for a period, or nil if the
+// period is missing or had no sales
+func averagePrice(insights *ItemInsights, period MetricsPeriod) *float64 {
+	metrics, ok := insights.Metrics[string(period)]
+	if !ok || metrics.SoldCount == 0 {
+		return nil
+	}
+	avg := metrics.AveragePrice
+	return &avg
+}
@@ -0,0 +1,196 @@
+package gocollect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// StagedSaleChangeDetector remembers the state last sent for each staged
+// sale by UpsertStagedSalesBatch, so that unchanged sales are skipped and
+// changed ones are patched
+type StagedSaleChangeDetector interface {
+	// Last returns the state last recorded for a partner sale id, including
+	// the ID the server assigned, or false if none was recorded
+	Last(partnerSaleID string) (*StagedSale, bool)
+	// Record stores sale, with its ID set, as the state last sent
+	Record(sale *StagedSale)
+}
+
+// NewMemoryChangeDetector returns a StagedSaleChangeDetector keeping a copy
+// of each sale last sent in memory
+func NewMemoryChangeDetector() StagedSaleChangeDetector {
+	return &memoryChangeDetector{sent: make(map[string][]byte)}
+}
+
+// memoryChangeDetector stores each sale as JSON, so that later changes to
+// the caller's slices and pointers do not reach the recorded state
+type memoryChangeDetector struct {
+	mu   sync.Mutex
+	sent map[string][]byte
+}
+
+func (d *memoryChangeDetector) Last(partnerSaleID string) (*StagedSale, bool) {
+	d.mu.Lock()
+	data, ok := d.sent[partnerSaleID]
+	d.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	sale := new(StagedSale)
+	if err := json.Unmarshal(data, sale); err != nil {
+		return nil, false
+	}
+	return sale, true
+}
+
+func (d *memoryChangeDetector) Record(sale *StagedSale) {
+	data, err := json.Marshal(sale)
+	if err != nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sent[sale.PartnerSaleID] = data
+}
+
+// upsertIgnoredFields are the JSON names of the staged sale fields left out
+// when comparing a sale with the state last sent: its ID, which the sync
+// loop may not know, and the fields set by the server
+var upsertIgnoredFields = map[string]bool{
+	"id":           true,
+	"updated_at":   true,
+	"item":         true,
+	"image_status": true,
+}
+
+// stagedSalePatch returns a merge patch of the fields of sale that differ
+// from last, by JSON name
+func stagedSalePatch(last, sale *StagedSale) map[string]interface{} {
+	old, new := reflect.ValueOf(*last), reflect.ValueOf(*sale)
+	patch := make(map[string]interface{})
+	for _, i := range changedFields(old, new, upsertIgnoredFields) {
+		patch[jsonFieldName(old.Type().Field(i))] = new.Field(i).Interface()
+	}
+	return patch
+}
+
+// UpsertOptions configures UpsertStagedSalesBatch
+type UpsertOptions struct {
+	// Detector remembers the state last sent, and must be reused across
+	// calls for unchanged sales to be skipped. Required.
+	Detector StagedSaleChangeDetector
+}
+
+// UpsertResult reports the outcome of UpsertStagedSalesBatch
+type UpsertResult struct {
+	Created int
+	Updated int
+	Skipped int
+	// Errors holds the failed sales' errors by partner sale id
+	Errors map[string]error
+}
+
+// upsertOutcome is what happened to one sale of a batch upsert
+type upsertOutcome int
+
+const (
+	upsertSkipped upsertOutcome = iota
+	upsertCreated
+	upsertUpdated
+	upsertFailed
+)
+
+// UpsertStagedSalesBatch sends the sales that changed since they were last
+// sent, skipping the others. A sale the detector has recorded is patched
+// with its changed fields only, under the ID the detector remembers unless
+// the sale has its own. Other sales are replaced with UpdateStagedSale if
+// they have an ID, and created otherwise; a created sale's ID is set from
+// the response. Sales are sent concurrently and a failed sale does not stop
+// the others. The batch is rejected if it holds a nil sale or repeats a
+// partner sale id.
+func (s *StagedSalesService) UpsertStagedSalesBatch(ctx context.Context, sales []*StagedSale, opts UpsertOptions) (*UpsertResult, error) {
+	if opts.Detector == nil {
+		return nil, errors.New("gocollect: UpsertOptions.Detector is required")
+	}
+	seen := make(map[string]bool, len(sales))
+	for i, sale := range sales {
+		if sale == nil {
+			return nil, fmt.Errorf("gocollect: sales[%d] is nil", i)
+		}
+		if seen[sale.PartnerSaleID] {
+			return nil, fmt.Errorf("gocollect: partner sale id %q appears more than once in the batch", sale.PartnerSaleID)
+		}
+		seen[sale.PartnerSaleID] = true
+	}
+
+	outcomes := make([]upsertOutcome, len(sales))
+	errs := make([]error, len(sales))
+	forEachConcurrently(ctx, len(sales), func(i int) {
+		outcomes[i], errs[i] = s.upsertStagedSale(ctx, sales[i], opts.Detector)
+	}, func(i int, err error) {
+		outcomes[i], errs[i] = upsertFailed, err
+	})
+
+	result := &UpsertResult{Errors: make(map[string]error)}
+	for i, outcome := range outcomes {
+		switch outcome {
+		case upsertCreated:
+			result.Created++
+		case upsertUpdated:
+			result.Updated++
+		case upsertSkipped:
+			result.Skipped++
+		case upsertFailed:
+			result.Errors[sales[i].PartnerSaleID] = errs[i]
+		}
+	}
+	return result, nil
+}
+
+func (s *StagedSalesService) upsertStagedSale(ctx context.Context, sale *StagedSale, detector StagedSaleChangeDetector) (upsertOutcome, error) {
+	if err := sale.Validate(); err != nil {
+		return upsertFailed, err
+	}
+	// The state is compared and recorded with the certification key in the
+	// canonical form it is sent in
+	sent := *sale
+	key, err := normalizedCertificationKey(sent.CertificationCompany, sent.CertificationKey)
+	if err != nil {
+		return upsertFailed, err
+	}
+	sent.CertificationKey = key
+
+	outcome := upsertUpdated
+	last, recorded := detector.Last(sale.PartnerSaleID)
+	if recorded && sale.ID == "" {
+		sale.ID = last.ID
+	}
+	switch {
+	case recorded && sale.ID != "":
+		patch := stagedSalePatch(last, &sent)
+		if len(patch) == 0 {
+			return upsertSkipped, nil
+		}
+		if _, err := s.PatchStagedSale(ctx, sale.ID, patch); err != nil {
+			return upsertFailed, err
+		}
+	case sale.ID != "":
+		if _, err := s.UpdateStagedSale(ctx, sale.ID, sale, ""); err != nil {
+			return upsertFailed, err
+		}
+	default:
+		created, err := s.createStagedSale(ctx, sale)
+		if err != nil {
+			return upsertFailed, err
+		}
+		sale.ID = created.ID
+		outcome = upsertCreated
+	}
+	sent.ID = sale.ID
+	detector.Record(&sent)
+	return outcome, nil
+}
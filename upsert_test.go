@@ -0,0 +1,118 @@
+package gocollect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUpsertStagedSalesBatch(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	patches := make(map[string]map[string]interface{})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+path.Base(r.URL.Path))
+		switch r.Method {
+		case http.MethodPost:
+			var sale StagedSale
+			json.NewDecoder(r.Body).Decode(&sale)
+			fmt.Fprintf(w, `{"data":{"id":"srv-%s","partner_sale_id":%q}}`, sale.PartnerSaleID, sale.PartnerSaleID)
+		case http.MethodPatch:
+			var patch map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&patch)
+			patches[path.Base(r.URL.Path)] = patch
+			w.Write([]byte(`{"data":{}}`))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	// listings rebuilds the sales from local state, without server ids, as
+	// a sync loop does every cycle
+	listings := func(price float64) []*StagedSale {
+		var sales []*StagedSale
+		for _, id := range []string{"1", "2"} {
+			p := price
+			sales = append(sales, &StagedSale{
+				PartnerSaleID: id,
+				CAM:           "Comics",
+				Title:         "X-Men #" + id,
+				URL:           "https://example.com/sale/" + id,
+				Format:        SaleFormatAuction,
+				Price:         &p,
+			})
+		}
+		return sales
+	}
+	detector := NewMemoryChangeDetector()
+	upsert := func(sales []*StagedSale) (*UpsertResult, []string) {
+		t.Helper()
+		mu.Lock()
+		requests = nil
+		mu.Unlock()
+		result, err := c.StagedSales.UpsertStagedSalesBatch(context.Background(), sales, UpsertOptions{Detector: detector})
+		if err != nil {
+			t.Fatalf("UpsertStagedSalesBatch: %v", err)
+		}
+		if len(result.Errors) != 0 {
+			t.Fatalf("UpsertStagedSalesBatch errors: %v", result.Errors)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return result, requests
+	}
+
+	if result, _ := upsert(listings(10)); result.Created != 2 {
+		t.Errorf("first upsert created %d sales, want 2", result.Created)
+	}
+
+	if result, requests := upsert(listings(10)); result.Skipped != 2 || len(requests) != 0 {
+		t.Errorf("unchanged upsert skipped %d sales and sent %v, want 2 skipped and no requests", result.Skipped, requests)
+	}
+
+	sales := listings(10)
+	*sales[1].Price = 12
+	result, requests := upsert(sales)
+	if result.Skipped != 1 || result.Updated != 1 {
+		t.Errorf("upsert = %+v, want 1 skipped and 1 updated", result)
+	}
+	if strings.Join(requests, ",") != "PATCH srv-2" {
+		t.Errorf("requests = %v, want a single patch of srv-2", requests)
+	}
+	if patch := patches["srv-2"]; len(patch) != 1 || patch["price"] != 12.0 {
+		t.Errorf("patch = %v, want only the price", patch)
+	}
+	if sales[1].ID != "srv-2" {
+		t.Errorf("ID = %q, want the remembered srv-2", sales[1].ID)
+	}
+}
+
+func TestUpsertStagedSalesBatchRejectsBadBatches(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+	sale := &StagedSale{PartnerSaleID: "1"}
+	tests := []struct {
+		name  string
+		sales []*StagedSale
+		want  string
+	}{
+		{name: "nil sale", sales: []*StagedSale{sale, nil}, want: "sales[1] is nil"},
+		{name: "repeated partner sale id", sales: []*StagedSale{sale, {PartnerSaleID: "1"}}, want: `partner sale id "1" appears more than once`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.StagedSales.UpsertStagedSalesBatch(context.Background(), tt.sales, UpsertOptions{Detector: NewMemoryChangeDetector()})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("UpsertStagedSalesBatch = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
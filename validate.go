@@ -0,0 +1,224 @@
+package gocollect
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// validateTags checks the fields of the struct v points to against their
+// validate tags, a comma-separated list of rules:
+//
+//	required    the field is set: not blank, zero or nil
+//	min=N       the number is at least N; nil pointers are skipped
+//	url         the string is an absolute http(s) URL, if set
+//	oneof=A B   the string is one of the space-separated values, if set
+//
+// Violations are reported under the field's JSON name.
+func validateTags(verr *ValidationError, v interface{}) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		rules := field.Tag.Get("validate")
+		if rules == "" {
+			continue
+		}
+		name := jsonFieldName(field)
+		value := rv.Field(i)
+		for _, rule := range strings.Split(rules, ",") {
+			rule, arg, _ := strings.Cut(rule, "=")
+			if !validateRule(verr, name, value, rule, arg) {
+				break
+			}
+		}
+	}
+}
+
+// validateRule applies one validate rule to a field, returning false if the
+// field's remaining rules should be skipped
+func validateRule(verr *ValidationError, name string, value reflect.Value, rule, arg string) bool {
+	switch rule {
+	case "required":
+		if isBlank(value) {
+			verr.add(name, "is required")
+			return false
+		}
+	case "min":
+		min, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			panic(fmt.Sprintf("gocollect: invalid min rule %q on %s", arg, name))
+		}
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return true
+			}
+			value = value.Elem()
+		}
+		if n, ok := numericValue(value); ok && n < min {
+			if min == 0 {
+				verr.add(name, "must not be negative")
+			} else {
+				verr.add(name, "must be at least %v", min)
+			}
+		}
+	case "url":
+		if raw := value.String(); raw != "" {
+			validateURL(verr, name, raw)
+		}
+	case "oneof":
+		if raw := value.String(); raw != "" && !containsString(strings.Fields(arg), raw) {
+			verr.add(name, "has unknown value %q", raw)
+		}
+	default:
+		panic(fmt.Sprintf("gocollect: unknown validate rule %q on %s", rule, name))
+	}
+	return true
+}
+
+// isBlank reports whether a field is unset for the required rule
+func isBlank(v reflect.Value) bool {
+	if v.Kind() == reflect.String {
+		return strings.TrimSpace(v.String()) == ""
+	}
+	return v.IsZero()
+}
+
+// numericValue returns the value of a numeric field as a float64
+func numericValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	}
+	return 0, false
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldViolation describes one invalid field of a record
+type FieldViolation struct {
+	Field   string
+	Message string
+}
+
+// ValidationError lists every problem found in a record. Index is the
+// record's position when validating a batch.
+type ValidationError struct {
+	Index      int
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + " " + v.Message
+	}
+	return "invalid record: " + strings.Join(parts, "; ")
+}
+
+// add records a violation
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	e.Violations = append(e.Violations, FieldViolation{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// errOrNil returns e if it has violations, nil otherwise
+func (e *ValidationError) errOrNil() error {
+	if len(e.Violations) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Validate checks the sold example locally, returning a *ValidationError
+// listing all problems found
+func (e *SoldExample) Validate() error {
+	verr := &ValidationError{}
+	validateTags(verr, e)
+	validateOriginalPrice(verr, e.OriginalPrice, e.OriginalCurrency)
+	validateCertificationKey(verr, e.CertificationCompany, e.CertificationKey)
+	return verr.errOrNil()
+}
+
+// Validate checks the staged sale locally, returning a *ValidationError
+// listing all problems found
+func (s *StagedSale) Validate() error {
+	verr := &ValidationError{}
+	validateTags(verr, s)
+	validateCertificationKey(verr, s.CertificationCompany, s.CertificationKey)
+	return verr.errOrNil()
+}
+
+// currencyCodePattern matches an ISO 4217 currency code
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// validateOriginalPrice checks that an original price and currency are set
+// together and well formed
+func validateOriginalPrice(verr *ValidationError, price *float64, currency string) {
+	switch {
+	case price == nil && currency == "":
+		return
+	case price == nil:
+		verr.add("original_price", "is required when original_currency is set")
+	case currency == "":
+		verr.add("original_currency", "is required when original_price is set")
+	}
+	if price != nil && *price <= 0 {
+		verr.add("original_price", "must be positive")
+	}
+	if currency != "" && !currencyCodePattern.MatchString(currency) {
+		verr.add("original_currency", "must be an ISO 4217 code such as \"GBP\"")
+	}
+}
+
+// ValidateSoldExamples validates a batch of sold examples without sending
+// anything, returning one *ValidationError per invalid record with Index set
+// to its position in examples
+func ValidateSoldExamples(examples []*SoldExample) []ValidationError {
+	var errs []ValidationError
+	for i, example := range examples {
+		if example == nil {
+			errs = append(errs, ValidationError{Index: i, Violations: []FieldViolation{{Field: "", Message: "record is nil"}}})
+			continue
+		}
+		if err := example.Validate(); err != nil {
+			verr := *err.(*ValidationError)
+			verr.Index = i
+			errs = append(errs, verr)
+		}
+	}
+	return errs
+}
+
+func validateURL(verr *ValidationError, field, raw string) {
+	if strings.TrimSpace(raw) == "" {
+		verr.add(field, "is required")
+		return
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		verr.add(field, "must be an absolute http(s) URL")
+	}
+}
+
+func validateCertificationKey(verr *ValidationError, company CertificationCompany, key *string) {
+	if key == nil || !isKnownCertificationCompany(company) {
+		return
+	}
+	if _, err := NormalizeCertificationKey(company, *key); err != nil {
+		verr.add("certification_key", "is not a valid %s certification number", company)
+	}
+}
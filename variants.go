@@ -0,0 +1,125 @@
+package gocollect
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetItemVariants retrieves the variants of an item, such as its alternate
+// covers. If the API does not provide the variants endpoint, they are found
+// by searching for the item's name.
+func (s *CollectiblesService) GetItemVariants(ctx context.Context, itemID int) ([]SearchItem, error) {
+	path := fmt.Sprintf("%s/item/%d/variants", s.client.apiBase(APICollectibles), itemID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var variants []SearchItem
+	_, err = s.client.do(req, &variants)
+	if err == nil {
+		return variants, nil
+	}
+	if !isEndpointMissing(err) {
+		return nil, err
+	}
+
+	item, err := s.GetItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	found, err := s.searchItems(ctx, SearchItemsOptions{Query: item.Name})
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range found {
+		if candidate.VariantOfItemID != nil && *candidate.VariantOfItemID == itemID {
+			variants = append(variants, candidate)
+		}
+	}
+	return variants, nil
+}
+
+// VariantInsights holds the insights of one item of a variant group
+type VariantInsights struct {
+	ItemID   int
+	Insights *ItemInsights
+	// Err is set when the item's insights could not be retrieved; the item
+	// is then left out of the aggregate
+	Err error
+}
+
+// VariantGroupInsights combines the insights of an item and its variants
+type VariantGroupInsights struct {
+	BaseItemID int
+	Grade      string
+	// Metrics aggregates the metrics of every variant by period: sold counts
+	// are summed, averages weighted by sold count, and low and high prices
+	// are the extremes across variants
+	Metrics map[string]Metrics
+	// Variants lists the base item first, then its variants
+	Variants []VariantInsights
+}
+
+// GetVariantGroupInsights retrieves the insights of an item and all its
+// variants at a grade and aggregates them. Items whose insights fail are
+// reported in Variants and left out of the aggregate; an error is returned
+// only if the variants cannot be listed.
+func (s *InsightsService) GetVariantGroupInsights(ctx context.Context, baseItemID int, grade string) (*VariantGroupInsights, error) {
+	variants, err := s.client.Collectibles.GetItemVariants(ctx, baseItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := []InsightsRequest{{ItemID: baseItemID, Grade: grade}}
+	for _, variant := range variants {
+		if variant.ItemID != baseItemID {
+			reqs = append(reqs, InsightsRequest{ItemID: variant.ItemID, Grade: grade})
+		}
+	}
+	results, err := s.GetItemInsightsBulk(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &VariantGroupInsights{
+		BaseItemID: baseItemID,
+		Grade:      grade,
+		Metrics:    make(map[string]Metrics),
+		Variants:   make([]VariantInsights, len(reqs)),
+	}
+	for i, result := range results {
+		group.Variants[i] = VariantInsights{ItemID: reqs[i].ItemID, Insights: result.Insights, Err: result.Err}
+		if result.Err != nil {
+			continue
+		}
+		for period, metrics := range result.Insights.Metrics {
+			group.Metrics[period] = combineMetrics(group.Metrics[period], metrics)
+		}
+	}
+	return group, nil
+}
+
+// combineMetrics merges the metrics of two items for the same period
+func combineMetrics(a, b Metrics) Metrics {
+	if a.SoldCount == 0 {
+		return b
+	}
+	if b.SoldCount == 0 {
+		return a
+	}
+	total := a.SoldCount + b.SoldCount
+	combined := Metrics{
+		SoldCount:    total,
+		LowPrice:     a.LowPrice,
+		HighPrice:    a.HighPrice,
+		AveragePrice: (a.AveragePrice*float64(a.SoldCount) + b.AveragePrice*float64(b.SoldCount)) / float64(total),
+	}
+	if b.LowPrice < combined.LowPrice {
+		combined.LowPrice = b.LowPrice
+	}
+	if b.HighPrice > combined.HighPrice {
+		combined.HighPrice = b.HighPrice
+	}
+	return combined
+}
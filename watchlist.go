@@ -0,0 +1,61 @@
+package gocollect
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// WatchlistService handles communication with the watchlist related endpoints
+type WatchlistService struct {
+	client *Client
+}
+
+// AddItem adds an item to the authenticated account's watchlist. Adding an
+// item already on the watchlist is not an error.
+func (s *WatchlistService) AddItem(ctx context.Context, itemID int) error {
+	body := struct {
+		ItemID int `json:"item_id"`
+	}{itemID}
+	req, err := s.client.newRequest(ctx, "POST", s.client.apiBase(APIResources)+"/watchlist", body)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
+
+// RemoveItem removes an item from the authenticated account's watchlist
+func (s *WatchlistService) RemoveItem(ctx context.Context, itemID int) error {
+	path := fmt.Sprintf("%s/watchlist/%d", s.client.apiBase(APIResources), itemID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
+
+// ListItems lists the items on the authenticated account's watchlist
+func (s *WatchlistService) ListItems(ctx context.Context, opts ListOptions) ([]SearchItem, *ListMeta, error) {
+	params := url.Values{}
+	opts.encode(params, s.client.defaultLimit)
+
+	path := listPath(s.client.apiBase(APIResources)+"/watchlist", params, opts)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response struct {
+		Data []SearchItem `json:"data"`
+		Meta *ListMeta    `json:"meta"`
+	}
+	resp, err := s.client.do(req, &response)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response.Data, s.client.withLinkHeader(resp, response.Meta), nil
+}
@@ -0,0 +1,132 @@
+package gocollect
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultWebhookTolerance is the maximum age of a webhook accepted by
+// VerifyWebhookSignature, limiting replays of captured deliveries
+const DefaultWebhookTolerance = 5 * time.Minute
+
+var (
+	// ErrInvalidWebhookSignature is returned when a webhook signature header is
+	// malformed or does not match the payload
+	ErrInvalidWebhookSignature = errors.New("gocollect: invalid webhook signature")
+
+	// ErrWebhookTimestampExpired is returned when a webhook's timestamp is
+	// outside the accepted tolerance
+	ErrWebhookTimestampExpired = errors.New("gocollect: webhook timestamp outside tolerance")
+)
+
+// VerifyWebhookSignature verifies a webhook delivery against its signature
+// header, which has the form "t=<unix timestamp>,v1=<signature>". The
+// signature is the hex-encoded HMAC-SHA256, keyed with the webhook secret, of
+// the timestamp, a period and the raw payload. Deliveries older than
+// DefaultWebhookTolerance are rejected.
+func VerifyWebhookSignature(payload []byte, header string, secret string) error {
+	return VerifyWebhookSignatureWithTolerance(payload, header, secret, DefaultWebhookTolerance)
+}
+
+// VerifyWebhookSignatureWithTolerance is like VerifyWebhookSignature with a
+// custom maximum age. A zero tolerance disables the timestamp check.
+func VerifyWebhookSignatureWithTolerance(payload []byte, header string, secret string, tolerance time.Duration) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return ErrInvalidWebhookSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+	if tolerance > 0 {
+		age := time.Since(time.Unix(unix, 0))
+		if age > tolerance || age < -tolerance {
+			return ErrWebhookTimestampExpired
+		}
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, signature := range signatures {
+		actual, err := hex.DecodeString(signature)
+		if err == nil && hmac.Equal(actual, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidWebhookSignature
+}
+
+// Webhook event types
+const (
+	WebhookEventFMVChanged = "item.fmv_changed"
+)
+
+// WebhookEvent is a webhook delivery. Data holds the event-specific payload,
+// decoded by the typed accessors such as FMVChanged.
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// FMVChangedEvent is the payload of an item.fmv_changed event
+type FMVChangedEvent struct {
+	ItemID      int      `json:"item_id"`
+	Grade       string   `json:"grade"`
+	Company     string   `json:"company"`
+	Label       string   `json:"label"`
+	PreviousFMV *float64 `json:"previous_fmv"`
+	FMV         *float64 `json:"fmv"`
+}
+
+// ParseWebhookEvent decodes a webhook payload. Verify the payload with
+// VerifyWebhookSignature before trusting it.
+func ParseWebhookEvent(payload []byte) (*WebhookEvent, error) {
+	event := new(WebhookEvent)
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, err
+	}
+	if event.Type == "" {
+		return nil, errors.New("gocollect: webhook event has no type")
+	}
+	return event, nil
+}
+
+// FMVChanged decodes the payload of an item.fmv_changed event
+func (e *WebhookEvent) FMVChanged() (*FMVChangedEvent, error) {
+	if e.Type != WebhookEventFMVChanged {
+		return nil, fmt.Errorf("gocollect: webhook event is %q, not %q", e.Type, WebhookEventFMVChanged)
+	}
+	data := new(FMVChangedEvent)
+	if err := json.Unmarshal(e.Data, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,89 @@
+package gocollect
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// itemWebPath is the path prefix of item pages on the GoCollect website
+const itemWebPath = "/app/item/"
+
+// ItemWebURL returns the public web page URL for an item, relative to the
+// client's base URL
+func (c *Client) ItemWebURL(itemID int) string {
+	return c.webURL(itemWebPath + strconv.Itoa(itemID))
+}
+
+// SearchItemWebURL returns the public web page URL for a search result,
+// preferring its slug over its id
+func (c *Client) SearchItemWebURL(item SearchItem) string {
+	if item.Slug == "" {
+		return c.ItemWebURL(item.ItemID)
+	}
+	return c.webURL(itemWebPath + item.Slug)
+}
+
+// SoldExampleWebURL returns the web page URL of the item a sold example was
+// matched to, or an empty string if it has no GoCollect item id
+func (c *Client) SoldExampleWebURL(example *SoldExample) string {
+	if example.GocollectItemID == nil {
+		return ""
+	}
+	return c.ItemWebURL(*example.GocollectItemID)
+}
+
+// StagedSaleWebURL returns the web page URL of the item a staged sale was
+// matched to, or an empty string if it has no GoCollect item id
+func (c *Client) StagedSaleWebURL(sale *StagedSale) string {
+	if sale.GocollectItemID == nil {
+		return ""
+	}
+	return c.ItemWebURL(*sale.GocollectItemID)
+}
+
+// webURL resolves an absolute path against the base URL
+func (c *Client) webURL(path string) string {
+	u := *c.baseURL
+	u.Path = path
+	u.RawPath = ""
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+// ItemRef identifies an item by GoCollect item id or by slug; exactly one
+// of the two is set
+type ItemRef struct {
+	ItemID int
+	Slug   string
+}
+
+// ParseItemURL extracts the item id or slug from the URL of an item page,
+// e.g. "https://gocollect.com/app/item/223124" or a URL returned by
+// SearchItemWebURL. The host is not checked, so URLs of other deployments
+// are accepted.
+func ParseItemURL(raw string) (ItemRef, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return ItemRef{}, fmt.Errorf("gocollect: invalid item URL %q: %w", raw, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ItemRef{}, fmt.Errorf("gocollect: invalid item URL %q: not an absolute http(s) URL", raw)
+	}
+
+	rest, ok := strings.CutPrefix(u.Path, itemWebPath)
+	segment, _, _ := strings.Cut(rest, "/")
+	if !ok || segment == "" {
+		return ItemRef{}, fmt.Errorf("gocollect: %q is not an item page URL", raw)
+	}
+	if isDigits(segment) {
+		id, err := strconv.Atoi(segment)
+		if err != nil || id <= 0 {
+			return ItemRef{}, fmt.Errorf("gocollect: %q has an invalid item id", raw)
+		}
+		return ItemRef{ItemID: id}, nil
+	}
+	return ItemRef{Slug: segment}, nil
+}